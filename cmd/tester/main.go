@@ -1,32 +1,77 @@
 package main
 
 import (
+	"context"
 	"flag"
 	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
 
 	"github.com/LNMMusic/tester/internal/application"
 )
 
+const (
+	// ExitCodeOK is the exit code when every case passed.
+	ExitCodeOK = 0
+	// ExitCodeFailed is the exit code when the assertions of some case failed or some case timed out.
+	ExitCodeFailed = 1
+	// ExitCodeError is the exit code when the cases could not be run.
+	ExitCodeError = 2
+	// ExitCodeInterrupted is the exit code when the run was interrupted by a signal.
+	ExitCodeInterrupted = 130
+)
+
 func main() {
+	os.Exit(run())
+}
+
+// run runs the tester and returns the exit code of the process.
+func run() (code int) {
 	// env
 	// ...
 
+	// signals
+	// - SIGINT and SIGTERM cancel the run, so that the case in flight is torn down and the reports are flushed
+	// - a second signal kills the process, once the first one is being handled
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	go func() {
+		<-ctx.Done()
+		stop()
+	}()
+
 	// cmd
 	// - flag: config file path
 	cfgFile := flag.String("config", "config.yaml", "config file path in yaml format")
 	flag.Parse()
-	
+
 	// application
 	// - config: from yaml
 	cfg, err := application.NewConfigApplicationDefaultFromYAML(*cfgFile)
 	if err != nil {
 		fmt.Println(err)
-		return
+		return ExitCodeError
 	}
 	a := application.NewApplicationDefault(cfg)
 	// - run
-	if err := a.Run(); err != nil {
+	rr, err := a.Run(ctx)
+	if err != nil {
 		fmt.Println(err)
-		return
+		if ctx.Err() != nil {
+			return ExitCodeInterrupted
+		}
+		return ExitCodeError
+	}
+
+	// exit code
+	switch {
+	case rr.Errored > 0:
+		code = ExitCodeError
+	case rr.Failed > 0 || rr.TimedOut > 0:
+		code = ExitCodeFailed
+	default:
+		code = ExitCodeOK
 	}
-}
\ No newline at end of file
+	return
+}
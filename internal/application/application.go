@@ -1,7 +1,16 @@
 package application
 
+import (
+	"context"
+
+	"github.com/LNMMusic/tester/internal/cases"
+)
+
 // Application is an interface of an application.
 type Application interface {
 	// Run runs the application.
-	Run() (err error)
+	// - rr is the aggregated result of the test cases that were processed
+	// - err is returned when the application could not run the test cases
+	// - canceling ctx interrupts the run, reporting the test cases processed so far
+	Run(ctx context.Context) (rr cases.RunResult, err error)
 }
\ No newline at end of file
@@ -1,20 +1,48 @@
 package application
 
 import (
+	"context"
 	"database/sql"
-	"encoding/json"
 	"errors"
 	"fmt"
-	"os"
+	"net/url"
+	"strings"
+	"time"
 
 	"github.com/LNMMusic/tester/internal"
 	"github.com/LNMMusic/tester/internal/cases"
 	"github.com/go-sql-driver/mysql"
+	_ "github.com/lib/pq"
+	_ "modernc.org/sqlite"
 )
 
 var (
 	// ErrApplicationRun is the error of the run of the application.
 	ErrApplicationRun = errors.New("application: run error")
+	// ErrUnknownDriver is the error returned when the database driver is not supported.
+	ErrUnknownDriver = errors.New("application: unknown database driver")
+	// ErrUnknownIsolation is the error returned when the database isolation strategy is not supported.
+	ErrUnknownIsolation = errors.New("application: unknown database isolation strategy")
+	// ErrInvalidDatabase is the error returned when the database config is incomplete.
+	ErrInvalidDatabase = errors.New("application: invalid database config")
+)
+
+const (
+	// DriverMySQL is the driver of MySQL databases.
+	DriverMySQL = "mysql"
+	// DriverPostgres is the driver of PostgreSQL databases.
+	DriverPostgres = "postgres"
+	// DriverSQLite is the driver of embedded SQLite databases.
+	DriverSQLite = "sqlite"
+)
+
+const (
+	// IsolationNone leaves the state of the database to the set-up and tear-down of each case.
+	IsolationNone = "none"
+	// IsolationTruncate empties the isolated tables before the first case and after each one.
+	IsolationTruncate = "truncate"
+	// IsolationSnapshot restores the rows of the isolated tables after each case.
+	IsolationSnapshot = "snapshot"
 )
 
 // NewApplicationDefault creates a new application.
@@ -24,7 +52,12 @@ func NewApplicationDefault(cfg *Config) (a *ApplicationDefault) {
 		Server: ServerConfig{
 			Address: "http://localhost:8080",
 		},
+		Readiness: ReadinessConfig{
+			Timeout:  30 * time.Second,
+			Interval: 250 * time.Millisecond,
+		},
 		Database: &DatabaseConfig{
+			Driver:   DriverMySQL,
 			Address:  "localhost:3306",
 		},
 		Cases: CasesConfig{
@@ -35,6 +68,13 @@ func NewApplicationDefault(cfg *Config) (a *ApplicationDefault) {
 				FilePath:  "./cases.json",
 				BatchSize: 10,
 			},
+			Runner: struct {
+				Workers int
+				Timeout time.Duration
+			}{
+				Workers: 1,
+				Timeout: 30 * time.Second,
+			},
 		},
 	}
 	if cfg != nil {
@@ -52,8 +92,20 @@ func NewApplicationDefault(cfg *Config) (a *ApplicationDefault) {
 type ServerConfig struct {
 	// server address
 	Address string
+	// server health check path, polled until ready ("/" by default)
+	HealthPath string
+	// status codes of a ready server (any 2xx code by default)
+	HealthCodes []int
+}
+type ReadinessConfig struct {
+	// maximum time to wait for the server and database to be ready (0 to not wait)
+	Timeout time.Duration
+	// first delay between attempts, doubled after each one
+	Interval time.Duration
 }
 type DatabaseConfig struct {
+	// database driver (mysql, postgres or sqlite)
+	Driver string
 	// database address
 	Address string
 	// database user
@@ -62,10 +114,19 @@ type DatabaseConfig struct {
 	Password string
 	// database name
 	Name string
+	// database file path, or ":memory:" for an in-memory database (sqlite only)
+	Path string
+	// database isolation of each case
+	Isolation struct {
+		// strategy (none, truncate or snapshot)
+		Strategy string
+		// tables to isolate, parents first
+		Tables []string
+	}
 }
 type CasesConfig struct {
 	Reader struct {
-		// cases file path
+		// cases file path, directory or glob pattern (e.g. cases/**/*.json)
 		FilePath string
 		// batch size
 		BatchSize int
@@ -73,14 +134,28 @@ type CasesConfig struct {
 	Reporter struct {
 		// excluded headers
 		ExcludedHeaders []string
+		// default body match mode (exact, subset or ignore_order)
+		Match string
+		// junit xml report file path (optional)
+		JUnitPath string
+		// json lines report file path (optional)
+		JSONPath string
+	}
+	Runner struct {
+		// number of cases tested concurrently
+		Workers int
+		// default maximum time to run each case, overridden by the timeout of the case (0 for no timeout)
+		Timeout time.Duration
 	}
 }
 // Config is the config of the application.
 type Config struct {
 	// server
 	Server ServerConfig
-	// database
+	// database (optional)
 	Database *DatabaseConfig
+	// readiness of the server and database
+	Readiness ReadinessConfig
 	// Cases
 	Cases CasesConfig
 }
@@ -92,54 +167,171 @@ type ApplicationDefault struct {
 }
 
 // Run runs the application.
-func (a *ApplicationDefault) Run() (err error) {
+func (a *ApplicationDefault) Run(ctx context.Context) (rr cases.RunResult, err error) {
 	// dependency injection
-	// - reader: file
-	f, err := os.Open(a.cfg.Cases.Reader.FilePath)
+	// - reader: files
+	files, err := cases.MatchFiles(a.cfg.Cases.Reader.FilePath)
 	if err != nil {
 		err = fmt.Errorf("%w - %v", ErrApplicationRun, err)
 		return
 	}
-	defer f.Close()
-	dc := json.NewDecoder(f)
 	// - reader: chan
 	ch := make(chan cases.CaseErr, a.cfg.Cases.Reader.BatchSize)
-	rd := cases.NewReaderJSON(dc, ch)
+	rd := cases.NewReaderFiles(files, ch)
 
-	// - casetester: dbexecuter
-	cfg := &mysql.Config{
-		User:                 a.cfg.Database.User,
-		Passwd:               a.cfg.Database.Password,
-		Net:                  "tcp",
-		Addr:                 a.cfg.Database.Address,
-		DBName:               a.cfg.Database.Name,
+	// - casetester: dbexecuter and isolator
+	//   > without a database section, cases that need one fail validation
+	var ex cases.DbExecuter = cases.NewDbExecuterNone()
+	var is cases.Isolator
+	var db *sql.DB
+	if a.cfg.Database != nil {
+		db, ex, err = openDatabase(a.cfg.Database)
+		if err != nil {
+			err = fmt.Errorf("%w - %v", ErrApplicationRun, err)
+			return
+		}
+		defer db.Close()
+		is, err = newIsolator(db, a.cfg.Database)
+		if err != nil {
+			err = fmt.Errorf("%w - %v", ErrApplicationRun, err)
+			return
+		}
 	}
-	db, err := sql.Open("mysql", cfg.FormatDSN())
-	if err != nil {
-		err = fmt.Errorf("%w - %v", ErrApplicationRun, err)
-		return
+	// - readiness
+	if a.cfg.Readiness.Timeout > 0 {
+		deps := []internal.Dependency{
+			internal.NewDependencyHTTP("server", strings.TrimSuffix(a.cfg.Server.Address, "/")+healthPath(a.cfg.Server.HealthPath), nil, a.cfg.Server.HealthCodes...),
+		}
+		if db != nil {
+			deps = append(deps, internal.NewDependencyDB("database", db))
+		}
+		err = internal.WaitReady(ctx, a.cfg.Readiness.Timeout, a.cfg.Readiness.Interval, deps...)
+		if err != nil {
+			err = fmt.Errorf("%w - %v", ErrApplicationRun, err)
+			return
+		}
 	}
-	defer db.Close()
-	ex := cases.NewDbExecuterMySQL(db)
 	// - casetester: requester
 	rq := cases.NewRequesterDefault(a.cfg.Server.Address, nil)
 	// - casetester: reporter
-	rp := cases.NewReporterDefault(a.cfg.Cases.Reporter.ExcludedHeaders)
+	rp := cases.NewReporterDefault(a.cfg.Cases.Reporter.ExcludedHeaders, cases.MatchMode(a.cfg.Cases.Reporter.Match), nil)
 	// - casetester: case tester
-	ct := internal.NewCaseTesterDefault(ex, rq, rp)
+	ct := internal.NewCaseTesterDefault(ex, rq, rp, is, a.cfg.Cases.Runner.Timeout)
 
+	// - tester: result reporters
+	rps := []cases.ResultReporter{rp}
+	if a.cfg.Cases.Reporter.JUnitPath != "" {
+		rps = append(rps, cases.NewReporterJUnit(a.cfg.Cases.Reporter.JUnitPath))
+	}
+	if a.cfg.Cases.Reporter.JSONPath != "" {
+		rps = append(rps, cases.NewReporterJSON(a.cfg.Cases.Reporter.JSONPath))
+	}
 	// - tester
-	ts := internal.NewTester(rd, ct)
+	ts := internal.NewTester(rd, ct, a.cfg.Cases.Runner.Workers, rps...)
 
 	// run
 	// - stream cases
-	go rd.Stream()
+	go rd.Stream(ctx)
 	// - test cases
-	err = ts.Run()
+	rr, err = ts.Run(ctx)
 	if err != nil {
 		err = fmt.Errorf("%w - %v", ErrApplicationRun, err)
 		return
 	}
 
 	return
-}
\ No newline at end of file
+}
+
+// healthPath returns the health check path of the server, "/" by default.
+func healthPath(path string) string {
+	if path == "" {
+		return "/"
+	}
+	if !strings.HasPrefix(path, "/") {
+		return "/" + path
+	}
+	return path
+}
+
+// openDatabase opens the database of the config and creates its executer.
+// - the driver is mysql by default
+func openDatabase(cfg *DatabaseConfig) (db *sql.DB, ex cases.DbExecuter, err error) {
+	dsn, err := cfg.DSN()
+	if err != nil {
+		return
+	}
+	db, err = sql.Open(string(dialect(cfg)), dsn)
+	if err != nil {
+		return
+	}
+	// - sqlite: a single connection, as in-memory databases live as long as their connection
+	//   and sqlite allows a single writer at a time
+	if cfg.Driver == DriverSQLite {
+		db.SetMaxOpenConns(1)
+	}
+
+	switch cfg.Driver {
+	case DriverPostgres:
+		ex = cases.NewDbExecuterPostgres(db)
+	case DriverSQLite:
+		ex = cases.NewDbExecuterSQLite(db)
+	default:
+		ex = cases.NewDbExecuterMySQL(db)
+	}
+	return
+}
+
+// DSN returns the data source name of the database, as expected by its driver.
+func (cfg *DatabaseConfig) DSN() (dsn string, err error) {
+	switch cfg.Driver {
+	case "", DriverMySQL:
+		// - from the defaults of the driver, as a zero config disables native passwords
+		mc := mysql.NewConfig()
+		mc.User = cfg.User
+		mc.Passwd = cfg.Password
+		mc.Net = "tcp"
+		mc.Addr = cfg.Address
+		mc.DBName = cfg.Name
+		dsn = mc.FormatDSN()
+	case DriverPostgres:
+		// - ssl is disabled, as test databases usually run locally
+		dsn = (&url.URL{
+			Scheme:   "postgres",
+			User:     url.UserPassword(cfg.User, cfg.Password),
+			Host:     cfg.Address,
+			Path:     "/" + cfg.Name,
+			RawQuery: "sslmode=disable",
+		}).String()
+	case DriverSQLite:
+		if cfg.Path == "" {
+			err = fmt.Errorf("%w - sqlite requires a path, or \":memory:\"", ErrInvalidDatabase)
+			return
+		}
+		dsn = cfg.Path
+	default:
+		err = fmt.Errorf("%w - %s", ErrUnknownDriver, cfg.Driver)
+	}
+	return
+}
+
+// dialect returns the SQL dialect of the database of the config.
+func dialect(cfg *DatabaseConfig) cases.Dialect {
+	if cfg.Driver == "" {
+		return cases.DialectMySQL
+	}
+	return cases.Dialect(cfg.Driver)
+}
+
+// newIsolator creates the isolator of the config, if any.
+func newIsolator(db *sql.DB, cfg *DatabaseConfig) (is cases.Isolator, err error) {
+	switch cfg.Isolation.Strategy {
+	case "", IsolationNone:
+	case IsolationTruncate:
+		is = cases.NewIsolatorTruncate(db, dialect(cfg), cfg.Isolation.Tables)
+	case IsolationSnapshot:
+		is = cases.NewIsolatorSnapshot(db, dialect(cfg), cfg.Isolation.Tables)
+	default:
+		err = fmt.Errorf("%w - %s", ErrUnknownIsolation, cfg.Isolation.Strategy)
+	}
+	return
+}
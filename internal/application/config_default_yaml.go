@@ -3,7 +3,9 @@ package application
 import (
 	"fmt"
 	"os"
+	"time"
 
+	"github.com/LNMMusic/tester/internal/cases"
 	"gopkg.in/yaml.v2"
 )
 
@@ -13,9 +15,22 @@ type ConfigApplicationDefaultYAML struct {
 	Server struct {
 		// server address
 		Address string `yaml:"address"`
+		// server health check path
+		HealthPath string `yaml:"health_path"`
+		// status codes of a ready server (e.g. [200, 401])
+		HealthCodes []int `yaml:"health_codes"`
 	} `yaml:"server"`
-	// database config
-	Database struct {
+	// readiness config
+	Readiness struct {
+		// maximum time to wait (e.g. 30s)
+		Timeout time.Duration `yaml:"timeout"`
+		// first delay between attempts (e.g. 250ms)
+		Interval time.Duration `yaml:"interval"`
+	} `yaml:"readiness"`
+	// database config (optional)
+	Database *struct {
+		// database driver (mysql, postgres or sqlite)
+		Driver string `yaml:"driver"`
 		// database address
 		Address string `yaml:"address"`
 		// database user
@@ -24,6 +39,13 @@ type ConfigApplicationDefaultYAML struct {
 		Password string `yaml:"password"`
 		// database name
 		Name string `yaml:"name"`
+		// database file path (sqlite only)
+		Path string `yaml:"path"`
+		// database isolation of each case
+		Isolation struct {
+			Strategy string `yaml:"strategy"`
+			Tables []string `yaml:"tables"`
+		} `yaml:"isolation"`
 	} `yaml:"database"`
 	// cases config
 	Cases struct {
@@ -33,7 +55,14 @@ type ConfigApplicationDefaultYAML struct {
 		} `yaml:"reader"`
 		Reporter struct {
 			ExcludedHeaders []string `yaml:"excluded_headers"`
+			Match string `yaml:"match"`
+			JUnitPath string `yaml:"junit_path"`
+			JSONPath string `yaml:"json_path"`
 		} `yaml:"reporter"`
+		Runner struct {
+			Workers int `yaml:"workers"`
+			Timeout time.Duration `yaml:"timeout"`
+		} `yaml:"runner"`
 	} `yaml:"cases"`
 }
 
@@ -56,16 +85,22 @@ func NewConfigApplicationDefaultFromYAML(filePath string) (cfg *Config, err erro
 		return nil, fmt.Errorf("application: decode config file error: %w", err)
 	}
 
+	// validate
+	err = cases.MatchMode(cfgYAML.Cases.Reporter.Match).Validate()
+	if err != nil {
+		return nil, fmt.Errorf("application: invalid config file: cases.reporter.match: %w", err)
+	}
+
 	// serialize
 	cfg = &Config{
 		Server: ServerConfig{
 			Address: cfgYAML.Server.Address,
+			HealthPath: cfgYAML.Server.HealthPath,
+			HealthCodes: cfgYAML.Server.HealthCodes,
 		},
-		Database: &DatabaseConfig{
-			Address: cfgYAML.Database.Address,
-			User: cfgYAML.Database.User,
-			Password: cfgYAML.Database.Password,
-			Name: cfgYAML.Database.Name,
+		Readiness: ReadinessConfig{
+			Timeout: cfgYAML.Readiness.Timeout,
+			Interval: cfgYAML.Readiness.Interval,
 		},
 		Cases: CasesConfig{
 			Reader: struct {
@@ -77,10 +112,41 @@ func NewConfigApplicationDefaultFromYAML(filePath string) (cfg *Config, err erro
 			},
 			Reporter: struct {
 				ExcludedHeaders []string
+				Match string
+				JUnitPath string
+				JSONPath string
 			}{
 				ExcludedHeaders: cfgYAML.Cases.Reporter.ExcludedHeaders,
+				Match: cfgYAML.Cases.Reporter.Match,
+				JUnitPath: cfgYAML.Cases.Reporter.JUnitPath,
+				JSONPath: cfgYAML.Cases.Reporter.JSONPath,
+			},
+			Runner: struct {
+				Workers int
+				Timeout time.Duration
+			}{
+				Workers: cfgYAML.Cases.Runner.Workers,
+				Timeout: cfgYAML.Cases.Runner.Timeout,
 			},
 		},
 	}
+	// - database: absent to run without a database
+	if cfgYAML.Database != nil {
+		cfg.Database = &DatabaseConfig{
+			Driver: cfgYAML.Database.Driver,
+			Address: cfgYAML.Database.Address,
+			User: cfgYAML.Database.User,
+			Password: cfgYAML.Database.Password,
+			Name: cfgYAML.Database.Name,
+			Path: cfgYAML.Database.Path,
+			Isolation: struct {
+				Strategy string
+				Tables []string
+			}{
+				Strategy: cfgYAML.Database.Isolation.Strategy,
+				Tables: cfgYAML.Database.Isolation.Tables,
+			},
+		}
+	}
 	return
 }
\ No newline at end of file
@@ -0,0 +1,234 @@
+package cases
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/textproto"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// BodyType is the type of the body of a request or of an expected response.
+type BodyType string
+
+const (
+	// BodyJSON is a body encoded as JSON (application/json).
+	BodyJSON BodyType = "json"
+	// BodyForm is an object of fields url-encoded as a form (application/x-www-form-urlencoded).
+	// - field values are strings, numbers, booleans or lists of them
+	BodyForm BodyType = "form"
+	// BodyMultipart is an object of fields and files encoded as a multipart form (multipart/form-data).
+	// - field values are as in forms, or {"file": path, "filename": name, "content_type": type} objects for files
+	BodyMultipart BodyType = "multipart"
+	// BodyText is a string sent as is (text/plain).
+	BodyText BodyType = "text"
+	// BodyBinary is a base64 string or a {"file": path} object sent as raw bytes (application/octet-stream).
+	BodyBinary BodyType = "binary"
+)
+
+var (
+	// ErrUnknownBodyType is the error returned when the body type is unknown.
+	ErrUnknownBodyType = errors.New("unknown body type")
+	// ErrInvalidBody is the error returned when the body does not fit its body type.
+	ErrInvalidBody = errors.New("invalid body")
+)
+
+// Resolve resolves a path relative to the file of the test case.
+func (c *Case) Resolve(path string) string {
+	if filepath.IsAbs(path) || c.File == "" {
+		return path
+	}
+	return filepath.Join(filepath.Dir(c.File), path)
+}
+
+// encodeBody encodes the body of the request of a test case by its body type.
+// - contentType is the content type matching the body type
+func encodeBody(c *Case) (body io.Reader, contentType string, err error) {
+	if c.Request.Body == nil {
+		return
+	}
+
+	switch c.Request.BodyType {
+	case "", BodyJSON:
+		var b []byte
+		b, err = json.Marshal(c.Request.Body)
+		if err != nil {
+			return
+		}
+		body, contentType = bytes.NewReader(b), "application/json"
+	case BodyForm:
+		var fields map[string]any
+		fields, err = bodyObject(c.Request.Body)
+		if err != nil {
+			return
+		}
+		form := url.Values{}
+		for k, v := range fields {
+			for _, s := range fieldValues(v) {
+				form.Add(k, s)
+			}
+		}
+		body, contentType = bytes.NewReader([]byte(form.Encode())), "application/x-www-form-urlencoded"
+	case BodyMultipart:
+		body, contentType, err = encodeMultipart(c)
+	case BodyText:
+		s, ok := c.Request.Body.(string)
+		if !ok {
+			err = fmt.Errorf("%w - text body must be a string", ErrInvalidBody)
+			return
+		}
+		body, contentType = bytes.NewReader([]byte(s)), "text/plain; charset=utf-8"
+	case BodyBinary:
+		var b []byte
+		b, err = binaryBody(c, c.Request.Body)
+		if err != nil {
+			return
+		}
+		body, contentType = bytes.NewReader(b), "application/octet-stream"
+	default:
+		err = fmt.Errorf("%w - %s", ErrUnknownBodyType, c.Request.BodyType)
+	}
+	return
+}
+
+// encodeMultipart encodes the body of a request as a multipart form, in sorted order of its fields.
+func encodeMultipart(c *Case) (body io.Reader, contentType string, err error) {
+	fields, err := bodyObject(c.Request.Body)
+	if err != nil {
+		return
+	}
+	names := make([]string, 0, len(fields))
+	for k := range fields {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+
+	var buf bytes.Buffer
+	mw := multipart.NewWriter(&buf)
+	for _, name := range names {
+		values, ok := fields[name].([]any)
+		if !ok {
+			values = []any{fields[name]}
+		}
+		for _, v := range values {
+			// - file
+			if f, ok := v.(map[string]any); ok {
+				err = writeFilePart(c, mw, name, f)
+				if err != nil {
+					return
+				}
+				continue
+			}
+			// - field
+			for _, s := range fieldValues(v) {
+				err = mw.WriteField(name, s)
+				if err != nil {
+					return
+				}
+			}
+		}
+	}
+	err = mw.Close()
+	if err != nil {
+		return
+	}
+
+	body, contentType = &buf, mw.FormDataContentType()
+	return
+}
+
+// writeFilePart writes a file part of a multipart form.
+func writeFilePart(c *Case, mw *multipart.Writer, name string, f map[string]any) (err error) {
+	path, _ := f["file"].(string)
+	if path == "" {
+		err = fmt.Errorf("%w - multipart file %s requires a file path", ErrInvalidBody, name)
+		return
+	}
+	b, err := os.ReadFile(c.Resolve(path))
+	if err != nil {
+		err = fmt.Errorf("%w - %v", ErrInvalidBody, err)
+		return
+	}
+	filename, _ := f["filename"].(string)
+	if filename == "" {
+		filename = filepath.Base(path)
+	}
+	contentType, _ := f["content_type"].(string)
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+
+	h := make(textproto.MIMEHeader)
+	h.Set("Content-Disposition", fmt.Sprintf(`form-data; name="%s"; filename="%s"`, escapeQuotes(name), escapeQuotes(filename)))
+	h.Set("Content-Type", contentType)
+	w, err := mw.CreatePart(h)
+	if err != nil {
+		return
+	}
+	_, err = w.Write(b)
+	return
+}
+
+// binaryBody returns the bytes of a binary body: a base64 string or a {"file": path} object.
+func binaryBody(c *Case, v any) (b []byte, err error) {
+	switch val := v.(type) {
+	case string:
+		b, err = base64.StdEncoding.DecodeString(val)
+		if err != nil {
+			err = fmt.Errorf("%w - binary body must be base64: %v", ErrInvalidBody, err)
+		}
+	case map[string]any:
+		path, _ := val["file"].(string)
+		if path == "" {
+			err = fmt.Errorf("%w - binary body requires a file path", ErrInvalidBody)
+			return
+		}
+		b, err = os.ReadFile(c.Resolve(path))
+		if err != nil {
+			err = fmt.Errorf("%w - %v", ErrInvalidBody, err)
+		}
+	default:
+		err = fmt.Errorf("%w - binary body must be a base64 string or a file", ErrInvalidBody)
+	}
+	return
+}
+
+// bodyObject returns the body as an object of fields.
+func bodyObject(v any) (fields map[string]any, err error) {
+	fields, ok := v.(map[string]any)
+	if !ok {
+		err = fmt.Errorf("%w - form body must be an object", ErrInvalidBody)
+	}
+	return
+}
+
+// fieldValues returns the values of a form field as strings.
+func fieldValues(v any) (values []string) {
+	switch val := v.(type) {
+	case []any:
+		for _, e := range val {
+			values = append(values, fieldValues(e)...)
+		}
+	case nil:
+		values = []string{""}
+	default:
+		values = []string{formatVariable(val)}
+	}
+	return
+}
+
+// quoteEscaper escapes the quotes of a multipart parameter, as mime/multipart does.
+var quoteEscaper = strings.NewReplacer("\\", "\\\\", `"`, "\\\"", "\r", "%0D", "\n", "%0A")
+
+// escapeQuotes escapes the quotes of a multipart parameter.
+func escapeQuotes(s string) string {
+	return quoteEscaper.Replace(s)
+}
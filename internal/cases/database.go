@@ -0,0 +1,126 @@
+package cases
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Verdict asserts the rows returned by the query of the expectation.
+func (ex DatabaseExpectation) Verdict(rows []map[string]any) (v Verdict, err error) {
+	expected := make([]any, len(ex.Rows))
+	for i, row := range ex.Rows {
+		expected[i] = row
+	}
+	actual := make([]any, len(rows))
+	for i, row := range rows {
+		actual[i] = row
+	}
+
+	d, err := DiffMatch(expected, actual, ex.Match)
+	if err != nil {
+		return
+	}
+	v = Verdict{Field: fmt.Sprintf("database (%s)", ex.Query), Valid: len(d) == 0, Expected: expected, Actual: actual, Diff: d}
+	return
+}
+
+// insertRows inserts a set of rows into a table with parameterized queries.
+// - each row is inserted with its own columns, in sorted order
+func insertRows(ctx context.Context, db *sql.DB, dialect Dialect, table string, rows []map[string]any) (err error) {
+	for i, row := range rows {
+		columns := make([]string, 0, len(row))
+		for c := range row {
+			columns = append(columns, c)
+		}
+		sort.Strings(columns)
+
+		quoted := make([]string, len(columns))
+		params := make([]string, len(columns))
+		args := make([]any, len(columns))
+		for j, c := range columns {
+			quoted[j] = dialect.quote(c)
+			params[j] = dialect.placeholder(j + 1)
+			args[j] = row[c]
+		}
+		q := fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s)", dialect.quote(table), strings.Join(quoted, ", "), strings.Join(params, ", "))
+		_, err = db.ExecContext(ctx, q, args...)
+		if err != nil {
+			err = &QueryError{Index: i, Query: q, Err: err}
+			return
+		}
+	}
+	return
+}
+
+// queryRows runs a query and returns its rows by column.
+// - values are normalized to their JSON counterparts, so they compare like bodies
+func queryRows(ctx context.Context, db *sql.DB, query string) (rs []map[string]any, err error) {
+	rows, err := db.QueryContext(ctx, query)
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+
+	types, err := rows.ColumnTypes()
+	if err != nil {
+		return
+	}
+	rs = []map[string]any{}
+	for rows.Next() {
+		values := make([]any, len(types))
+		ptrs := make([]any, len(types))
+		for i := range values {
+			ptrs[i] = &values[i]
+		}
+		err = rows.Scan(ptrs...)
+		if err != nil {
+			return
+		}
+		row := make(map[string]any, len(types))
+		for i, ct := range types {
+			row[ct.Name()] = normalizeValue(values[i], ct.DatabaseTypeName())
+		}
+		rs = append(rs, row)
+	}
+	err = rows.Err()
+	return
+}
+
+// normalizeValue converts a value scanned from a database to its JSON counterpart.
+// - raw bytes of numeric columns (e.g. mysql text protocol) are parsed as numbers, the rest are strings
+// - times are formatted as RFC 3339
+func normalizeValue(v any, dbType string) any {
+	switch val := v.(type) {
+	case []byte:
+		if isNumericType(dbType) {
+			if f, err := strconv.ParseFloat(string(val), 64); err == nil {
+				return f
+			}
+		}
+		return string(val)
+	case int64:
+		return float64(val)
+	case float32:
+		return float64(val)
+	case time.Time:
+		return val.Format(time.RFC3339Nano)
+	default:
+		return v
+	}
+}
+
+// isNumericType reports whether a database type name is numeric.
+func isNumericType(dbType string) bool {
+	t := strings.TrimPrefix(strings.ToUpper(dbType), "UNSIGNED ")
+	switch t {
+	case "TINYINT", "SMALLINT", "MEDIUMINT", "INT", "INTEGER", "BIGINT",
+		"INT2", "INT4", "INT8", "DECIMAL", "NUMERIC", "FLOAT", "FLOAT4", "FLOAT8", "DOUBLE", "REAL":
+		return true
+	}
+	return false
+}
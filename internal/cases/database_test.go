@@ -0,0 +1,60 @@
+package cases_test
+
+import (
+	"testing"
+
+	"github.com/LNMMusic/tester/internal/cases"
+
+	"github.com/stretchr/testify/require"
+)
+
+// Tests for DatabaseExpectation Verdict
+func TestDatabaseExpectation_Verdict(t *testing.T) {
+	t.Run("case 1 - rows match with matchers", func(t *testing.T) {
+		// arrange
+		ex := cases.DatabaseExpectation{
+			Query: "SELECT id, title FROM tasks",
+			Rows: []map[string]any{
+				{"id": map[string]any{"$type": "number"}, "title": "task 1"},
+			},
+		}
+
+		// act
+		v, err := ex.Verdict([]map[string]any{{"id": 1.0, "title": "task 1"}})
+
+		// assert
+		require.NoError(t, err)
+		require.True(t, v.Valid)
+		require.Equal(t, "database (SELECT id, title FROM tasks)", v.Field)
+	})
+
+	t.Run("case 2 - rows mismatch", func(t *testing.T) {
+		// arrange
+		ex := cases.DatabaseExpectation{
+			Query: "SELECT title FROM tasks",
+			Rows:  []map[string]any{{"title": "task 1"}},
+		}
+
+		// act
+		v, err := ex.Verdict([]map[string]any{{"title": "task 2"}, {"title": "task 3"}})
+
+		// assert
+		require.NoError(t, err)
+		require.False(t, v.Valid)
+		require.Equal(t, []cases.Difference{
+			{Path: "/0/title", Kind: cases.DiffChanged, Expected: "task 1", Actual: "task 2"},
+			{Path: "/1", Kind: cases.DiffAdded, Actual: map[string]any{"title": "task 3"}},
+		}, v.Diff)
+	})
+
+	t.Run("case 3 - error unknown match mode", func(t *testing.T) {
+		// arrange
+		ex := cases.DatabaseExpectation{Match: "fuzzy"}
+
+		// act
+		_, err := ex.Verdict(nil)
+
+		// assert
+		require.ErrorIs(t, err, cases.ErrUnknownMatchMode)
+	})
+}
@@ -1,7 +1,62 @@
 package cases
 
+import (
+	"context"
+	"fmt"
+)
+
 // DbExecuter is an interface for executing queries on a database.
+// - queries are canceled when the context is done
 type DbExecuter interface {
 	// Exec executes queries on the database.
-	Exec(queries ...string) (err error)
-}
\ No newline at end of file
+	Exec(ctx context.Context, queries ...string) (err error)
+	// Query runs a query on the database and returns its rows by column.
+	Query(ctx context.Context, query string) (rows []map[string]any, err error)
+	// Insert inserts a set of rows into a table.
+	Insert(ctx context.Context, table string, rows []map[string]any) (err error)
+}
+
+// DbValidator is implemented by the database executers that can not run every test case.
+type DbValidator interface {
+	// Validate validates that a test case can be run on the database, before running it.
+	Validate(c *Case) (err error)
+}
+
+// DbDialect is implemented by the database executers of sql databases.
+type DbDialect interface {
+	// Dialect returns the SQL dialect of the database.
+	Dialect() Dialect
+}
+
+// QueryError is the error of a query run on the database.
+// - the error of the driver is wrapped, so its details can be inspected with errors.As (e.g. *mysql.MySQLError)
+type QueryError struct {
+	// Index is the index of the query in its set of queries, SingleQuery if it was run on its own.
+	Index int
+	// Query is the text of the query.
+	Query string
+	// Case is the name of the test case the query belongs to, if known.
+	Case string
+	// Err is the error of the driver.
+	Err error
+}
+
+// SingleQuery is the index of a query error of a query run on its own, not as part of a set.
+const SingleQuery = -1
+
+// Error returns the message of the error.
+func (e *QueryError) Error() string {
+	msg := "error executing query"
+	if e.Index != SingleQuery {
+		msg += fmt.Sprintf(" %d", e.Index)
+	}
+	if e.Case != "" {
+		msg += fmt.Sprintf(" of case '%s'", e.Case)
+	}
+	return fmt.Sprintf("%s: %s - %v", msg, e.Query, e.Err)
+}
+
+// Unwrap returns the error of the driver.
+func (e *QueryError) Unwrap() error {
+	return e.Err
+}
@@ -1,6 +1,10 @@
 package cases
 
-import "github.com/stretchr/testify/mock"
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
 
 // NewDbExecuterMock creates a new dbexecuter mock.
 func NewDbExecuterMock() *DbExecuterMock {
@@ -13,8 +17,27 @@ type DbExecuterMock struct {
 }
 
 // Exec mocks base method.
-func (m *DbExecuterMock) Exec(queries ...string) (err error) {
-	args := m.Called(queries)
+func (m *DbExecuterMock) Exec(ctx context.Context, queries ...string) (err error) {
+	args := m.Called(ctx, queries)
+
+	err = args.Error(0)
+
+	return
+}
+
+// Query mocks base method.
+func (m *DbExecuterMock) Query(ctx context.Context, query string) (rows []map[string]any, err error) {
+	args := m.Called(ctx, query)
+
+	rows = args.Get(0).([]map[string]any)
+	err = args.Error(1)
+
+	return
+}
+
+// Insert mocks base method.
+func (m *DbExecuterMock) Insert(ctx context.Context, table string, rows []map[string]any) (err error) {
+	args := m.Called(ctx, table, rows)
 
 	err = args.Error(0)
 
@@ -1,33 +1,16 @@
 package cases
 
-import (
-	"database/sql"
-	"fmt"
-)
+import "database/sql"
 
 // NewDbExecuterMySQL creates a new MySQL database executer.
 func NewDbExecuterMySQL(db *sql.DB) *DbExecuterMySQL {
 	return &DbExecuterMySQL{
-		db: db,
+		DbExecuterSQL: NewDbExecuterSQL(db, DialectMySQL),
 	}
 }
 
 // DbExecuterMySQL is a MySQL database executer.
+// - queries are run by the shared sql executer, with the mysql dialect
 type DbExecuterMySQL struct {
-	// db is the database to execute queries on.
-	db *sql.DB
+	*DbExecuterSQL
 }
-
-// Exec executes queries on the database.
-func (e *DbExecuterMySQL) Exec(queries ...string) (err error) {
-	// execute queries
-	for i, q := range queries {
-		_, err = e.db.Exec(q)
-		if err != nil {
-			err = fmt.Errorf("error executing query %d", i)
-			return
-		}
-	}
-
-	return
-}
\ No newline at end of file
@@ -0,0 +1,26 @@
+package cases_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/LNMMusic/tester/internal/cases"
+
+	"github.com/stretchr/testify/require"
+)
+
+// Tests for DbExecuterMySQL Insert
+func TestDbExecuterMySQL_Insert(t *testing.T) {
+	t.Run("case 1 - mysql dialect", func(t *testing.T) {
+		// arrange
+		db, r := newRecorderDB(t, "")
+		ex := cases.NewDbExecuterMySQL(db)
+
+		// act
+		err := ex.Insert(context.Background(), "tasks", []map[string]any{{"id": 1}})
+
+		// assert
+		require.NoError(t, err)
+		require.Equal(t, []string{"INSERT INTO `tasks` (`id`) VALUES (?) [1]"}, r.Queries())
+	})
+}
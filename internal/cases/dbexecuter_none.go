@@ -0,0 +1,74 @@
+package cases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	// ErrNoDatabase is the error returned when a test case needs a database but none is configured.
+	ErrNoDatabase = errors.New("no database configured")
+)
+
+// NewDbExecuterNone creates a new database executer for runs without a database.
+func NewDbExecuterNone() *DbExecuterNone {
+	return &DbExecuterNone{}
+}
+
+// DbExecuterNone is a database executer for runs without a database.
+// - empty sets of queries are a no-op, any query is an error
+type DbExecuterNone struct{}
+
+// Exec executes queries on the database.
+func (e *DbExecuterNone) Exec(ctx context.Context, queries ...string) (err error) {
+	if len(queries) > 0 {
+		err = fmt.Errorf("%w - %d queries", ErrNoDatabase, len(queries))
+		return
+	}
+
+	return
+}
+
+// Query runs a query on the database and returns its rows by column.
+func (e *DbExecuterNone) Query(ctx context.Context, query string) (rows []map[string]any, err error) {
+	err = fmt.Errorf("%w - %s", ErrNoDatabase, query)
+	return
+}
+
+// Insert inserts a set of rows into a table.
+func (e *DbExecuterNone) Insert(ctx context.Context, table string, rows []map[string]any) (err error) {
+	err = fmt.Errorf("%w - insert into %s", ErrNoDatabase, table)
+	return
+}
+
+// Validate validates that a test case does not need a database.
+func (e *DbExecuterNone) Validate(c *Case) (err error) {
+	var sections []string
+	if len(c.Database.SetUp) > 0 {
+		sections = append(sections, "set_up")
+	}
+	if len(c.Database.TearDown) > 0 {
+		sections = append(sections, "tear_down")
+	}
+	if len(c.Database.Fixtures) > 0 {
+		sections = append(sections, "fixtures")
+	}
+	if len(c.Database.SQLFiles) > 0 {
+		sections = append(sections, "sql_files")
+	}
+	expectDatabase := len(c.ExpectDatabase) > 0
+	for _, s := range c.Scenario.Steps {
+		expectDatabase = expectDatabase || len(s.ExpectDatabase) > 0
+	}
+	if expectDatabase {
+		sections = append(sections, "expect_database")
+	}
+
+	if len(sections) > 0 {
+		err = fmt.Errorf("%w - the case declares %s", ErrNoDatabase, strings.Join(sections, ", "))
+		return
+	}
+	return
+}
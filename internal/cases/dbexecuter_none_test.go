@@ -0,0 +1,66 @@
+package cases_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/LNMMusic/tester/internal/cases"
+
+	"github.com/stretchr/testify/require"
+)
+
+// Tests for DbExecuterNone Exec
+func TestDbExecuterNone_Exec(t *testing.T) {
+	t.Run("case 1 - success without queries", func(t *testing.T) {
+		// arrange
+		ex := cases.NewDbExecuterNone()
+
+		// act
+		err := ex.Exec(context.Background(), )
+
+		// assert
+		require.NoError(t, err)
+	})
+
+	t.Run("case 2 - error with queries", func(t *testing.T) {
+		// arrange
+		ex := cases.NewDbExecuterNone()
+
+		// act
+		err := ex.Exec(context.Background(), "DELETE FROM tasks")
+
+		// assert
+		require.ErrorIs(t, err, cases.ErrNoDatabase)
+	})
+}
+
+// Tests for DbExecuterNone Validate
+func TestDbExecuterNone_Validate(t *testing.T) {
+	t.Run("case 1 - success without database sections", func(t *testing.T) {
+		// arrange
+		ex := cases.NewDbExecuterNone()
+
+		// act
+		err := ex.Validate(&cases.Case{Request: cases.Request{Method: "GET", Path: "/health"}})
+
+		// assert
+		require.NoError(t, err)
+	})
+
+	t.Run("case 2 - error with database sections", func(t *testing.T) {
+		// arrange
+		ex := cases.NewDbExecuterNone()
+
+		// act
+		err := ex.Validate(&cases.Case{
+			Database: cases.Database{TearDown: []string{"DELETE FROM tasks"}},
+			Scenario: cases.Scenario{Steps: []cases.Step{
+				{ExpectDatabase: []cases.DatabaseExpectation{{Query: "SELECT * FROM tasks"}}},
+			}},
+		})
+
+		// assert
+		require.ErrorIs(t, err, cases.ErrNoDatabase)
+		require.EqualError(t, err, "no database configured - the case declares tear_down, expect_database")
+	})
+}
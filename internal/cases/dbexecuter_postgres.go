@@ -0,0 +1,16 @@
+package cases
+
+import "database/sql"
+
+// NewDbExecuterPostgres creates a new PostgreSQL database executer.
+func NewDbExecuterPostgres(db *sql.DB) *DbExecuterPostgres {
+	return &DbExecuterPostgres{
+		DbExecuterSQL: NewDbExecuterSQL(db, DialectPostgres),
+	}
+}
+
+// DbExecuterPostgres is a PostgreSQL database executer.
+// - queries are run by the shared sql executer, with the postgres dialect
+type DbExecuterPostgres struct {
+	*DbExecuterSQL
+}
@@ -0,0 +1,65 @@
+package cases
+
+import (
+	"context"
+	"database/sql"
+)
+
+// NewDbExecuterSQL creates a new database executer of a sql database.
+// - dialect is the SQL dialect used to generate the queries of the inserts (mysql by default)
+func NewDbExecuterSQL(db *sql.DB, dialect Dialect) *DbExecuterSQL {
+	// default config
+	defaultDialect := DialectMySQL
+	if dialect != "" {
+		defaultDialect = dialect
+	}
+
+	return &DbExecuterSQL{
+		db:      db,
+		dialect: defaultDialect,
+	}
+}
+
+// DbExecuterSQL is a database executer of a sql database (mysql, postgres or sqlite).
+type DbExecuterSQL struct {
+	// db is the database to execute queries on.
+	db *sql.DB
+	// dialect is the SQL dialect of the database.
+	dialect Dialect
+}
+
+// Dialect returns the SQL dialect of the database.
+func (e *DbExecuterSQL) Dialect() Dialect {
+	return e.dialect
+}
+
+// Exec executes queries on the database.
+func (e *DbExecuterSQL) Exec(ctx context.Context, queries ...string) (err error) {
+	// execute queries
+	for i, q := range queries {
+		_, err = e.db.ExecContext(ctx, q)
+		if err != nil {
+			err = &QueryError{Index: i, Query: q, Err: err}
+			return
+		}
+	}
+
+	return
+}
+
+// Query runs a query on the database and returns its rows by column.
+func (e *DbExecuterSQL) Query(ctx context.Context, query string) (rows []map[string]any, err error) {
+	rows, err = queryRows(ctx, e.db, query)
+	if err != nil {
+		err = &QueryError{Index: SingleQuery, Query: query, Err: err}
+		return
+	}
+
+	return
+}
+
+// Insert inserts a set of rows into a table.
+func (e *DbExecuterSQL) Insert(ctx context.Context, table string, rows []map[string]any) (err error) {
+	err = insertRows(ctx, e.db, e.dialect, table, rows)
+	return
+}
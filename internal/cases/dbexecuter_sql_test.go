@@ -0,0 +1,245 @@
+package cases_test
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/LNMMusic/tester/internal/cases"
+
+	"github.com/stretchr/testify/require"
+	_ "modernc.org/sqlite"
+)
+
+// Tests for DbExecuterSQL Exec
+func TestDbExecuterSQL_Exec(t *testing.T) {
+	// arrange
+	// - in-memory databases live as long as their connection
+	db, err := sql.Open("sqlite", ":memory:")
+	require.NoError(t, err)
+	db.SetMaxOpenConns(1)
+	defer db.Close()
+	ex := cases.NewDbExecuterSQL(db, cases.DialectSQLite)
+
+	t.Run("case 1 - success to execute queries", func(t *testing.T) {
+		// act
+		err := ex.Exec(context.Background(), 
+			"CREATE TABLE tasks (id INTEGER PRIMARY KEY, title TEXT NOT NULL)",
+			"INSERT INTO tasks (title) VALUES ('task 1'), ('task 2')",
+		)
+
+		// assert
+		require.NoError(t, err)
+		var n int
+		require.NoError(t, db.QueryRow("SELECT COUNT(*) FROM tasks").Scan(&n))
+		require.Equal(t, 2, n)
+	})
+
+	t.Run("case 2 - error executing a query", func(t *testing.T) {
+		// act
+		err := ex.Exec(context.Background(), 
+			"DELETE FROM tasks",
+			"INSERT INTO missing (title) VALUES ('task 1')",
+		)
+
+		// assert
+		var qe *cases.QueryError
+		require.ErrorAs(t, err, &qe)
+		require.Equal(t, 1, qe.Index)
+		require.Equal(t, "INSERT INTO missing (title) VALUES ('task 1')", qe.Query)
+		require.ErrorContains(t, err, "error executing query 1: INSERT INTO missing (title) VALUES ('task 1') - ")
+	})
+}
+
+// Tests for DbExecuterSQL Query
+func TestDbExecuterSQL_Query(t *testing.T) {
+	// arrange
+	db, err := sql.Open("sqlite", ":memory:")
+	require.NoError(t, err)
+	db.SetMaxOpenConns(1)
+	defer db.Close()
+	ex := cases.NewDbExecuterSQL(db, cases.DialectSQLite)
+	require.NoError(t, ex.Exec(context.Background(), 
+		"CREATE TABLE tasks (id INTEGER PRIMARY KEY, title TEXT NOT NULL, done BOOLEAN, score REAL)",
+		"INSERT INTO tasks (title, done, score) VALUES ('task 1', false, 1.5), ('task 2', true, NULL)",
+	))
+
+	t.Run("case 1 - success to query rows", func(t *testing.T) {
+		// act
+		rows, err := ex.Query(context.Background(), "SELECT id, title, score FROM tasks ORDER BY id")
+
+		// assert
+		require.NoError(t, err)
+		require.Equal(t, []map[string]any{
+			{"id": 1.0, "title": "task 1", "score": 1.5},
+			{"id": 2.0, "title": "task 2", "score": nil},
+		}, rows)
+	})
+
+	t.Run("case 2 - success to query no rows", func(t *testing.T) {
+		// act
+		rows, err := ex.Query(context.Background(), "SELECT id FROM tasks WHERE id = 3")
+
+		// assert
+		require.NoError(t, err)
+		require.Equal(t, []map[string]any{}, rows)
+	})
+
+	t.Run("case 3 - error running a query", func(t *testing.T) {
+		// act
+		_, err := ex.Query(context.Background(), "SELECT id FROM missing")
+
+		// assert
+		var qe *cases.QueryError
+		require.ErrorAs(t, err, &qe)
+		require.Equal(t, cases.SingleQuery, qe.Index)
+		require.Equal(t, "SELECT id FROM missing", qe.Query)
+		require.ErrorContains(t, err, "error executing query: SELECT id FROM missing - ")
+	})
+}
+
+// Tests for DbExecuterSQL Insert
+func TestDbExecuterSQL_Insert(t *testing.T) {
+	// arrange
+	db, err := sql.Open("sqlite", ":memory:")
+	require.NoError(t, err)
+	db.SetMaxOpenConns(1)
+	defer db.Close()
+	ex := cases.NewDbExecuterSQL(db, cases.DialectSQLite)
+	require.NoError(t, ex.Exec(context.Background(), "CREATE TABLE tasks (id INTEGER PRIMARY KEY, title TEXT NOT NULL, done BOOLEAN DEFAULT false)"))
+
+	t.Run("case 1 - success to insert rows", func(t *testing.T) {
+		// act
+		err := ex.Insert(context.Background(), "tasks", []map[string]any{
+			{"id": 1, "title": "it's task 1"},
+			{"id": 2, "title": "task 2", "done": true},
+		})
+
+		// assert
+		require.NoError(t, err)
+		rows, err := ex.Query(context.Background(), "SELECT id, title FROM tasks ORDER BY id")
+		require.NoError(t, err)
+		require.Equal(t, []map[string]any{
+			{"id": 1.0, "title": "it's task 1"},
+			{"id": 2.0, "title": "task 2"},
+		}, rows)
+	})
+
+	t.Run("case 2 - error inserting a row", func(t *testing.T) {
+		// act
+		err := ex.Insert(context.Background(), "tasks", []map[string]any{
+			{"id": 3, "title": "task 3"},
+			{"id": 4},
+		})
+
+		// assert
+		var qe *cases.QueryError
+		require.ErrorAs(t, err, &qe)
+		require.Equal(t, 1, qe.Index)
+		require.Equal(t, `INSERT INTO "tasks" ("id") VALUES (?)`, qe.Query)
+	})
+}
+
+// recorder is a sql driver that records the queries it executes, instead of running them.
+// - queries containing fail return an error
+type recorder struct {
+	// mu guards the queries.
+	mu sync.Mutex
+	// queries is the set of executed queries with their arguments, in order.
+	queries []string
+	// fail is the text of the queries that fail, if any.
+	fail string
+}
+
+// newRecorderDB creates a database backed by a recorder.
+func newRecorderDB(t *testing.T, fail string) (db *sql.DB, r *recorder) {
+	r = &recorder{fail: fail}
+	db = sql.OpenDB(r)
+	t.Cleanup(func() { db.Close() })
+	return
+}
+
+// Queries returns the recorded queries.
+func (r *recorder) Queries() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string(nil), r.queries...)
+}
+
+// Connect opens a connection to the recorder.
+func (r *recorder) Connect(ctx context.Context) (driver.Conn, error) { return recorderConn{r}, nil }
+
+// Driver returns the recorder as a driver.
+func (r *recorder) Driver() driver.Driver { return r }
+
+// Open opens a connection to the recorder.
+func (r *recorder) Open(name string) (driver.Conn, error) { return recorderConn{r}, nil }
+
+// recorderConn is a connection to a recorder.
+type recorderConn struct {
+	r *recorder
+}
+
+// ExecContext records a query.
+func (c recorderConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	c.r.mu.Lock()
+	defer c.r.mu.Unlock()
+	q := query
+	for _, a := range args {
+		q += fmt.Sprintf(" [%v]", a.Value)
+	}
+	c.r.queries = append(c.r.queries, q)
+	if c.r.fail != "" && strings.Contains(query, c.r.fail) {
+		return nil, errors.New("recorder: query failed")
+	}
+	return driver.RowsAffected(1), nil
+}
+
+// Prepare is not supported.
+func (c recorderConn) Prepare(query string) (driver.Stmt, error) {
+	return nil, errors.New("recorder: prepare not supported")
+}
+
+// Close closes the connection.
+func (c recorderConn) Close() error { return nil }
+
+// Begin is not supported.
+func (c recorderConn) Begin() (driver.Tx, error) {
+	return nil, errors.New("recorder: transactions not supported")
+}
+
+// Tests for DbExecuterSQL Insert by dialect
+func TestDbExecuterSQL_Insert_Dialect(t *testing.T) {
+	tcs := []struct {
+		name    string
+		dialect cases.Dialect
+		table   string
+		query   string
+	}{
+		{"case 1 - mysql", cases.DialectMySQL, "tasks", "INSERT INTO `tasks` (`id`, `title`) VALUES (?, ?) [1] [it's task 1]"},
+		{"case 2 - mysql default", "", "tasks", "INSERT INTO `tasks` (`id`, `title`) VALUES (?, ?) [1] [it's task 1]"},
+		{"case 3 - postgres", cases.DialectPostgres, "tasks", `INSERT INTO "tasks" ("id", "title") VALUES ($1, $2) [1] [it's task 1]`},
+		{"case 4 - postgres schema", cases.DialectPostgres, "public.tasks", `INSERT INTO "public"."tasks" ("id", "title") VALUES ($1, $2) [1] [it's task 1]`},
+		{"case 5 - postgres quoted name", cases.DialectPostgres, `my"tasks`, `INSERT INTO "my""tasks" ("id", "title") VALUES ($1, $2) [1] [it's task 1]`},
+		{"case 6 - sqlite", cases.DialectSQLite, "tasks", `INSERT INTO "tasks" ("id", "title") VALUES (?, ?) [1] [it's task 1]`},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			// arrange
+			db, r := newRecorderDB(t, "")
+			ex := cases.NewDbExecuterSQL(db, tc.dialect)
+
+			// act
+			err := ex.Insert(context.Background(), tc.table, []map[string]any{{"title": "it's task 1", "id": 1}})
+
+			// assert
+			require.NoError(t, err)
+			require.Equal(t, []string{tc.query}, r.Queries())
+		})
+	}
+}
@@ -0,0 +1,16 @@
+package cases
+
+import "database/sql"
+
+// NewDbExecuterSQLite creates a new SQLite database executer.
+func NewDbExecuterSQLite(db *sql.DB) *DbExecuterSQLite {
+	return &DbExecuterSQLite{
+		DbExecuterSQL: NewDbExecuterSQL(db, DialectSQLite),
+	}
+}
+
+// DbExecuterSQLite is a SQLite database executer.
+// - queries are run by the shared sql executer, with the sqlite dialect
+type DbExecuterSQLite struct {
+	*DbExecuterSQL
+}
@@ -0,0 +1,359 @@
+package cases
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// DiffKind is the kind of a difference between two JSON values.
+type DiffKind string
+
+const (
+	// DiffAdded is a value present in the actual value but not in the expected one.
+	DiffAdded DiffKind = "added"
+	// DiffRemoved is a value present in the expected value but not in the actual one.
+	DiffRemoved DiffKind = "removed"
+	// DiffChanged is a value whose content differs.
+	DiffChanged DiffKind = "changed"
+	// DiffType is a value whose JSON type differs.
+	DiffType DiffKind = "type"
+	// DiffMatcher is a value that does not satisfy the matcher of the expected value.
+	DiffMatcher DiffKind = "matcher"
+)
+
+// Difference is a difference between an expected and an actual JSON value.
+type Difference struct {
+	// Path is the JSON pointer of the value (RFC 6901), empty for the root.
+	Path string `json:"path"`
+	// Kind is the kind of the difference.
+	Kind DiffKind `json:"kind"`
+	// Expected is the expected value, if any.
+	Expected any `json:"expected,omitempty"`
+	// Actual is the actual value, if any.
+	Actual any `json:"actual,omitempty"`
+	// Message describes the difference, if any.
+	Message string `json:"message,omitempty"`
+}
+
+// MatchMode is the mode used to match an expected JSON value against the actual one.
+type MatchMode string
+
+const (
+	// MatchExact requires both values to be equal.
+	MatchExact MatchMode = "exact"
+	// MatchSubset requires the fields of the expected objects to be present and equal,
+	// ignoring the extra fields of the actual objects.
+	MatchSubset MatchMode = "subset"
+	// MatchIgnoreOrder requires both values to be equal, ignoring the order of the arrays.
+	MatchIgnoreOrder MatchMode = "ignore_order"
+)
+
+var (
+	// ErrUnknownMatchMode is the error returned when the match mode is unknown.
+	ErrUnknownMatchMode = errors.New("unknown match mode")
+)
+
+// Diff returns the differences between two decoded JSON values.
+// - object keys are walked in sorted order, so the differences are deterministic
+func Diff(expected, actual any) (d []Difference) {
+	d, _ = DiffMatch(expected, actual, MatchExact)
+	return
+}
+
+// Validate returns ErrUnknownMatchMode if the mode is not empty nor a known one.
+func (m MatchMode) Validate() (err error) {
+	switch m {
+	case "", MatchExact, MatchSubset, MatchIgnoreOrder:
+	default:
+		err = fmt.Errorf("%w - %s", ErrUnknownMatchMode, m)
+	}
+	return
+}
+
+// DiffMatch returns the differences between two decoded JSON values under a match mode.
+// - an empty mode is an exact match
+func DiffMatch(expected, actual any, mode MatchMode) (d []Difference, err error) {
+	df := differ{}
+	switch mode {
+	case "", MatchExact:
+	case MatchSubset:
+		df.subset = true
+	case MatchIgnoreOrder:
+		df.ignoreOrder = true
+	default:
+		err = fmt.Errorf("%w - %s", ErrUnknownMatchMode, mode)
+		return
+	}
+
+	d = df.diff("", expected, actual, d)
+	return
+}
+
+// differ walks two JSON values looking for differences.
+type differ struct {
+	// subset is true if the extra fields of the actual objects are ignored.
+	subset bool
+	// ignoreOrder is true if arrays are compared regardless of the order of their elements.
+	ignoreOrder bool
+	// literal is true if matchers are compared as plain objects.
+	literal bool
+}
+
+// diff appends the differences between two values found under a path.
+func (df differ) diff(path string, expected, actual any, d []Difference) []Difference {
+	// matcher
+	if m, ok := asMatcher(expected); ok && !df.literal {
+		if msg, ok := m.match(actual); !ok {
+			d = append(d, Difference{Path: path, Kind: DiffMatcher, Expected: expected, Actual: actual, Message: msg})
+		}
+		return d
+	}
+
+	// type
+	te, ta := jsonType(expected), jsonType(actual)
+	if te != ta {
+		return append(d, Difference{Path: path, Kind: DiffType, Expected: expected, Actual: actual})
+	}
+
+	switch e := expected.(type) {
+	case map[string]any:
+		a := actual.(map[string]any)
+		for _, k := range unionKeys(e, a) {
+			ve, okE := e[k]
+			va, okA := a[k]
+			p := path + "/" + escapePointer(k)
+			switch {
+			case !okA:
+				if m, ok := asMatcher(ve); ok && !df.literal && m.absent() {
+					continue
+				}
+				d = append(d, Difference{Path: p, Kind: DiffRemoved, Expected: ve})
+			case !okE:
+				if !df.subset {
+					d = append(d, Difference{Path: p, Kind: DiffAdded, Actual: va})
+				}
+			default:
+				d = df.diff(p, ve, va, d)
+			}
+		}
+	case []any:
+		a := actual.([]any)
+		if df.ignoreOrder {
+			return df.diffUnordered(path, e, a, d)
+		}
+		for i := 0; i < len(e) || i < len(a); i++ {
+			p := path + "/" + strconv.Itoa(i)
+			switch {
+			case i >= len(a):
+				d = append(d, Difference{Path: p, Kind: DiffRemoved, Expected: e[i]})
+			case i >= len(e):
+				d = append(d, Difference{Path: p, Kind: DiffAdded, Actual: a[i]})
+			default:
+				d = df.diff(p, e[i], a[i], d)
+			}
+		}
+	default:
+		if !equalScalar(expected, actual) {
+			d = append(d, Difference{Path: path, Kind: DiffChanged, Expected: expected, Actual: actual})
+		}
+	}
+	return d
+}
+
+// diffUnordered appends the differences between two arrays regardless of the order of their elements.
+// - elements are paired with a maximum bipartite matching, so that a loose expectation (e.g. a matcher)
+//   does not take the only actual element a stricter one could match
+// - unpaired expected elements are reported as removed, unpaired actual elements as added
+func (df differ) diffUnordered(path string, expected, actual []any, d []Difference) []Difference {
+	// candidates: the actual elements each expected element matches
+	candidates := make([][]int, len(expected))
+	for i, e := range expected {
+		for j, a := range actual {
+			if len(df.diff("", e, a, nil)) == 0 {
+				candidates[i] = append(candidates[i], j)
+			}
+		}
+	}
+
+	// pairing: pairs[j] is the expected element paired with the actual element j, -1 if none
+	pairs := make([]int, len(actual))
+	for j := range pairs {
+		pairs[j] = -1
+	}
+	paired := make([]bool, len(expected))
+	for i := range expected {
+		paired[i] = augment(i, candidates, pairs, make([]bool, len(actual)))
+	}
+
+	for i, e := range expected {
+		if !paired[i] {
+			d = append(d, Difference{Path: path + "/" + strconv.Itoa(i), Kind: DiffRemoved, Expected: e})
+		}
+	}
+	for j, a := range actual {
+		if pairs[j] < 0 {
+			d = append(d, Difference{Path: path + "/" + strconv.Itoa(j), Kind: DiffAdded, Actual: a})
+		}
+	}
+	return d
+}
+
+// augment looks for an augmenting path from the expected element i, pairing it if one is found.
+// - an actual element already paired is taken over if its expected element can be paired with another one
+func augment(i int, candidates [][]int, pairs []int, visited []bool) bool {
+	for _, j := range candidates[i] {
+		if visited[j] {
+			continue
+		}
+		visited[j] = true
+		if pairs[j] < 0 || augment(pairs[j], candidates, pairs, visited) {
+			pairs[j] = i
+			return true
+		}
+	}
+	return false
+}
+
+// jsonType returns the JSON type of a decoded value.
+func jsonType(v any) string {
+	switch v.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case string:
+		return "string"
+	case float64, float32, int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, json.Number:
+		return "number"
+	case []any:
+		return "array"
+	case map[string]any:
+		return "object"
+	default:
+		return fmt.Sprintf("%T", v)
+	}
+}
+
+// equalScalar reports whether two scalars of the same JSON type are equal.
+// - numbers are compared by value regardless of their Go type
+func equalScalar(expected, actual any) bool {
+	if jsonType(expected) == "number" {
+		e, _ := toFloat(expected)
+		a, _ := toFloat(actual)
+		return e == a
+	}
+	return expected == actual
+}
+
+// toFloat converts a number of any Go type to float64.
+func toFloat(v any) (f float64, ok bool) {
+	ok = true
+	switch n := v.(type) {
+	case float64:
+		f = n
+	case float32:
+		f = float64(n)
+	case int:
+		f = float64(n)
+	case int8:
+		f = float64(n)
+	case int16:
+		f = float64(n)
+	case int32:
+		f = float64(n)
+	case int64:
+		f = float64(n)
+	case uint:
+		f = float64(n)
+	case uint8:
+		f = float64(n)
+	case uint16:
+		f = float64(n)
+	case uint32:
+		f = float64(n)
+	case uint64:
+		f = float64(n)
+	case json.Number:
+		var err error
+		f, err = n.Float64()
+		ok = err == nil
+	default:
+		ok = false
+	}
+	return
+}
+
+// unionKeys returns the sorted union of the keys of two objects.
+func unionKeys(a, b map[string]any) (keys []string) {
+	for k := range a {
+		keys = append(keys, k)
+	}
+	for k := range b {
+		if _, ok := a[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	return
+}
+
+// escapePointer escapes a key as a JSON pointer reference token.
+func escapePointer(k string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(k)
+}
+
+// ansi colors of the diff.
+const (
+	ansiRed    = "\033[31m"
+	ansiGreen  = "\033[32m"
+	ansiYellow = "\033[33m"
+	ansiReset  = "\033[0m"
+)
+
+// FormatDiff formats a set of differences as a compact unified view.
+// - removed (expected) values are prefixed by `-`, added (actual) values by `+`
+// - type mismatches and unsatisfied matchers are prefixed by `!`
+func FormatDiff(d []Difference, color bool) string {
+	paint := func(c, s string) string {
+		if !color {
+			return s
+		}
+		return c + s + ansiReset
+	}
+
+	var sb strings.Builder
+	for _, df := range d {
+		path := df.Path
+		if path == "" {
+			path = "/"
+		}
+		switch df.Kind {
+		case DiffRemoved:
+			sb.WriteString(paint(ansiRed, fmt.Sprintf("  - %s: %s", path, compactJSON(df.Expected))) + "\n")
+		case DiffAdded:
+			sb.WriteString(paint(ansiGreen, fmt.Sprintf("  + %s: %s", path, compactJSON(df.Actual))) + "\n")
+		case DiffChanged:
+			sb.WriteString(paint(ansiRed, fmt.Sprintf("  - %s: %s", path, compactJSON(df.Expected))) + "\n")
+			sb.WriteString(paint(ansiGreen, fmt.Sprintf("  + %s: %s", path, compactJSON(df.Actual))) + "\n")
+		case DiffMatcher:
+			sb.WriteString(paint(ansiYellow, fmt.Sprintf("  ! %s: %s %s", path, compactJSON(df.Actual), df.Message)) + "\n")
+		case DiffType:
+			sb.WriteString(paint(ansiYellow, fmt.Sprintf("  ! %s: expected %s %s, actual %s %s",
+				path, jsonType(df.Expected), compactJSON(df.Expected), jsonType(df.Actual), compactJSON(df.Actual))) + "\n")
+		}
+	}
+	return sb.String()
+}
+
+// compactJSON formats a value as compact JSON, falling back to %v.
+func compactJSON(v any) string {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprintf("%v", v)
+	}
+	return string(b)
+}
@@ -0,0 +1,190 @@
+package cases_test
+
+import (
+	"testing"
+
+	"github.com/LNMMusic/tester/internal/cases"
+
+	"github.com/stretchr/testify/require"
+)
+
+// Tests for Diff
+func TestDiff(t *testing.T) {
+	t.Run("case 1 - no differences", func(t *testing.T) {
+		// arrange
+		expected := map[string]any{"a": 1.0, "b": []any{"x", true, nil}}
+		actual := map[string]any{"a": 1, "b": []any{"x", true, nil}}
+
+		// act
+		d := cases.Diff(expected, actual)
+
+		// assert
+		require.Empty(t, d)
+	})
+
+	t.Run("case 2 - nested differences", func(t *testing.T) {
+		// arrange
+		expected := map[string]any{
+			"message": "task found",
+			"data": map[string]any{
+				"id":    1.0,
+				"done":  false,
+				"tags":  []any{"a", "b"},
+				"a/b~c": "x",
+			},
+		}
+		actual := map[string]any{
+			"message": "task found",
+			"data": map[string]any{
+				"id":    "1",
+				"done":  true,
+				"tags":  []any{"a"},
+				"extra": 1.0,
+			},
+		}
+
+		// act
+		d := cases.Diff(expected, actual)
+
+		// assert
+		require.Equal(t, []cases.Difference{
+			{Path: "/data/a~1b~0c", Kind: cases.DiffRemoved, Expected: "x"},
+			{Path: "/data/done", Kind: cases.DiffChanged, Expected: false, Actual: true},
+			{Path: "/data/extra", Kind: cases.DiffAdded, Actual: 1.0},
+			{Path: "/data/id", Kind: cases.DiffType, Expected: 1.0, Actual: "1"},
+			{Path: "/data/tags/1", Kind: cases.DiffRemoved, Expected: "b"},
+		}, d)
+	})
+
+	t.Run("case 3 - root type mismatch", func(t *testing.T) {
+		// act
+		d := cases.Diff(map[string]any{}, nil)
+
+		// assert
+		require.Equal(t, []cases.Difference{
+			{Path: "", Kind: cases.DiffType, Expected: map[string]any{}, Actual: nil},
+		}, d)
+	})
+}
+
+// Tests for FormatDiff
+func TestFormatDiff(t *testing.T) {
+	// arrange
+	d := []cases.Difference{
+		{Path: "/a", Kind: cases.DiffRemoved, Expected: "x"},
+		{Path: "/b", Kind: cases.DiffAdded, Actual: 1.0},
+		{Path: "/c", Kind: cases.DiffChanged, Expected: false, Actual: true},
+		{Path: "", Kind: cases.DiffType, Expected: map[string]any{}, Actual: nil},
+	}
+
+	t.Run("case 1 - plain", func(t *testing.T) {
+		// act
+		s := cases.FormatDiff(d, false)
+
+		// assert
+		require.Equal(t, "  - /a: \"x\"\n"+
+			"  + /b: 1\n"+
+			"  - /c: false\n"+
+			"  + /c: true\n"+
+			"  ! /: expected object {}, actual null null\n", s)
+	})
+
+	t.Run("case 2 - colored", func(t *testing.T) {
+		// act
+		s := cases.FormatDiff(d[:1], true)
+
+		// assert
+		require.Equal(t, "\033[31m  - /a: \"x\"\033[0m\n", s)
+	})
+}
+
+// Tests for DiffMatch
+func TestDiffMatch(t *testing.T) {
+	t.Run("case 1 - subset ignores extra fields", func(t *testing.T) {
+		// arrange
+		expected := map[string]any{"data": map[string]any{"id": 1.0}}
+		actual := map[string]any{"data": map[string]any{"id": 1.0, "created_at": "2023"}, "meta": nil}
+
+		// act
+		d, err := cases.DiffMatch(expected, actual, cases.MatchSubset)
+
+		// assert
+		require.NoError(t, err)
+		require.Empty(t, d)
+	})
+
+	t.Run("case 2 - subset reports missing and changed fields", func(t *testing.T) {
+		// arrange
+		expected := map[string]any{"id": 1.0, "title": "task 1"}
+		actual := map[string]any{"id": 2.0}
+
+		// act
+		d, err := cases.DiffMatch(expected, actual, cases.MatchSubset)
+
+		// assert
+		require.NoError(t, err)
+		require.Equal(t, []cases.Difference{
+			{Path: "/id", Kind: cases.DiffChanged, Expected: 1.0, Actual: 2.0},
+			{Path: "/title", Kind: cases.DiffRemoved, Expected: "task 1"},
+		}, d)
+	})
+
+	t.Run("case 3 - ignore order matches arrays as sets", func(t *testing.T) {
+		// arrange
+		expected := []any{map[string]any{"id": 1.0}, map[string]any{"id": 2.0}, "a"}
+		actual := []any{"a", map[string]any{"id": 2.0}, map[string]any{"id": 1.0}}
+
+		// act
+		d, err := cases.DiffMatch(expected, actual, cases.MatchIgnoreOrder)
+
+		// assert
+		require.NoError(t, err)
+		require.Empty(t, d)
+	})
+
+	t.Run("case 4 - ignore order reports unpaired elements", func(t *testing.T) {
+		// arrange
+		expected := []any{1.0, 2.0, 2.0}
+		actual := []any{2.0, 3.0, 1.0}
+
+		// act
+		d, err := cases.DiffMatch(expected, actual, cases.MatchIgnoreOrder)
+
+		// assert
+		require.NoError(t, err)
+		require.Equal(t, []cases.Difference{
+			{Path: "/2", Kind: cases.DiffRemoved, Expected: 2.0},
+			{Path: "/1", Kind: cases.DiffAdded, Actual: 3.0},
+		}, d)
+	})
+
+	t.Run("case 5 - error unknown mode", func(t *testing.T) {
+		// act
+		d, err := cases.DiffMatch(nil, nil, "partial")
+
+		// assert
+		require.ErrorIs(t, err, cases.ErrUnknownMatchMode)
+		require.Nil(t, d)
+	})
+
+	t.Run("case 6 - ignore order pairs loose matchers last", func(t *testing.T) {
+		// arrange
+		// - a greedy pairing would match the $type matcher with "a", leaving "a" unpaired
+		expected := []any{map[string]any{"$type": "string"}, "a"}
+		actual := []any{"a", "b"}
+
+		// act
+		d, err := cases.DiffMatch(expected, actual, cases.MatchIgnoreOrder)
+
+		// assert
+		require.NoError(t, err)
+		require.Empty(t, d)
+	})
+
+	t.Run("case 7 - validate match modes", func(t *testing.T) {
+		// act & assert
+		require.NoError(t, cases.MatchMode("").Validate())
+		require.NoError(t, cases.MatchSubset.Validate())
+		require.ErrorIs(t, cases.MatchMode("partial").Validate(), cases.ErrUnknownMatchMode)
+	})
+}
@@ -0,0 +1,250 @@
+package cases
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	// ErrFixture is the error returned when a fixture or sql file can not be loaded.
+	ErrFixture = errors.New("fixture error")
+)
+
+// Fixture is a set of rows to insert into a table.
+type Fixture struct {
+	// Table is the name of the table.
+	Table string
+	// Rows is the set of rows, by column.
+	Rows []map[string]any
+}
+
+// NewFixtureLoader creates a new loader of fixtures and sql files.
+func NewFixtureLoader() *FixtureLoader {
+	return &FixtureLoader{}
+}
+
+// FixtureLoader loads fixtures and sql files, caching them by path so they can be shared across test cases.
+// - fixtures are YAML files mapping tables to their rows (in order), or CSV files named after their table
+//   whose first record holds the columns
+// - sql files are split into statements by the rules of their dialect
+type FixtureLoader struct {
+	// fixtures is the cache of fixtures by path.
+	fixtures sync.Map
+	// scripts is the cache of statements of sql files by dialect and path.
+	scripts sync.Map
+}
+
+// Fixtures loads a fixture file.
+func (l *FixtureLoader) Fixtures(path string) (fx []Fixture, err error) {
+	if cached, ok := l.fixtures.Load(path); ok {
+		fx = cached.([]Fixture)
+		return
+	}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		fx, err = loadFixturesYAML(path)
+	case ".csv":
+		fx, err = loadFixturesCSV(path)
+	default:
+		err = fmt.Errorf("unsupported fixture extension %s", filepath.Ext(path))
+	}
+	if err != nil {
+		err = fmt.Errorf("%w - %s: %v", ErrFixture, path, err)
+		return
+	}
+
+	l.fixtures.Store(path, fx)
+	return
+}
+
+// Statements loads the statements of a sql file of a dialect.
+func (l *FixtureLoader) Statements(path string, dialect Dialect) (stmts []string, err error) {
+	key := string(dialect) + ":" + path
+	if cached, ok := l.scripts.Load(key); ok {
+		stmts = cached.([]string)
+		return
+	}
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		err = fmt.Errorf("%w - %s: %v", ErrFixture, path, err)
+		return
+	}
+	stmts = SplitSQL(string(b), dialect)
+
+	l.scripts.Store(path, stmts)
+	return
+}
+
+// loadFixturesYAML loads the fixtures of a YAML file, keeping the order of its tables.
+func loadFixturesYAML(path string) (fx []Fixture, err error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return
+	}
+	var doc yaml.Node
+	err = yaml.Unmarshal(b, &doc)
+	if err != nil {
+		return
+	}
+	if len(doc.Content) == 0 {
+		return
+	}
+	root := doc.Content[0]
+	if root.Kind != yaml.MappingNode {
+		err = errors.New("must map tables to rows")
+		return
+	}
+
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		f := Fixture{Table: root.Content[i].Value}
+		err = root.Content[i+1].Decode(&f.Rows)
+		if err != nil {
+			err = fmt.Errorf("table %s: %v", f.Table, err)
+			return
+		}
+		fx = append(fx, f)
+	}
+	return
+}
+
+// csvNull is the value of a CSV field inserted as NULL, as in the exports of mysql and postgres.
+const csvNull = `\N`
+
+// loadFixturesCSV loads the fixture of a CSV file, named after its table.
+// - fields are inserted as strings, except \N which is inserted as NULL
+func loadFixturesCSV(path string) (fx []Fixture, err error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return
+	}
+	defer file.Close()
+
+	records, err := csv.NewReader(file).ReadAll()
+	if err != nil {
+		return
+	}
+	if len(records) == 0 {
+		err = errors.New("missing header record")
+		return
+	}
+
+	f := Fixture{Table: strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))}
+	columns := records[0]
+	for _, record := range records[1:] {
+		row := make(map[string]any, len(columns))
+		for i, c := range columns {
+			if record[i] == csvNull {
+				row[c] = nil
+				continue
+			}
+			row[c] = record[i]
+		}
+		f.Rows = append(f.Rows, row)
+	}
+	fx = []Fixture{f}
+	return
+}
+
+// SplitSQL splits a sql script of a dialect into its statements.
+// - semicolons within quotes and comments (-- and /* */) do not end a statement
+// - mysql: # also starts a comment, and a backslash escapes the next character of a quoted string
+// - postgres: semicolons within dollar-quoted bodies ($$...$$ or $tag$...$tag$) do not end a statement
+func SplitSQL(script string, dialect Dialect) (stmts []string) {
+	mysql := dialect == DialectMySQL
+	var sb strings.Builder
+	flush := func() {
+		if s := strings.TrimSpace(sb.String()); s != "" {
+			stmts = append(stmts, s)
+		}
+		sb.Reset()
+	}
+
+	for i := 0; i < len(script); i++ {
+		ch := script[i]
+		switch {
+		// - quoted string or identifier
+		case ch == '\'' || ch == '"' || ch == '`':
+			end := i + 1
+			for end < len(script) {
+				if script[end] == ch {
+					// - doubled quote escapes itself
+					if end+1 < len(script) && script[end+1] == ch {
+						end += 2
+						continue
+					}
+					break
+				}
+				if script[end] == '\\' && mysql && ch != '`' {
+					end++
+				}
+				end++
+			}
+			if end >= len(script) {
+				end = len(script) - 1
+			}
+			sb.WriteString(script[i : end+1])
+			i = end
+		// - dollar-quoted body
+		case ch == '$' && dialect == DialectPostgres && dollarTag(script[i:]) != "":
+			tag := dollarTag(script[i:])
+			end := strings.Index(script[i+len(tag):], tag)
+			if end < 0 {
+				sb.WriteString(script[i:])
+				i = len(script)
+				continue
+			}
+			end += i + 2*len(tag)
+			sb.WriteString(script[i:end])
+			i = end - 1
+		// - line comment
+		case strings.HasPrefix(script[i:], "--") || ch == '#' && mysql:
+			end := strings.IndexByte(script[i:], '\n')
+			if end < 0 {
+				i = len(script)
+				continue
+			}
+			i += end
+			sb.WriteByte('\n')
+		// - block comment
+		case strings.HasPrefix(script[i:], "/*"):
+			end := strings.Index(script[i+2:], "*/")
+			if end < 0 {
+				i = len(script)
+				continue
+			}
+			i += end + 3
+			sb.WriteByte(' ')
+		case ch == ';':
+			flush()
+		default:
+			sb.WriteByte(ch)
+		}
+	}
+	flush()
+	return
+}
+
+// dollarTag returns the opening tag of a dollar-quoted body at the start of a script (e.g. $$ or $body$), if any.
+// - tags are identifiers, so positional parameters such as $1 are not tags
+func dollarTag(script string) string {
+	for i := 1; i < len(script); i++ {
+		ch := script[i]
+		switch {
+		case ch == '$':
+			return script[:i+1]
+		case ch == '_' || ch >= 'a' && ch <= 'z' || ch >= 'A' && ch <= 'Z' || i > 1 && ch >= '0' && ch <= '9':
+		default:
+			return ""
+		}
+	}
+	return ""
+}
@@ -0,0 +1,150 @@
+package cases_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/LNMMusic/tester/internal/cases"
+
+	"github.com/stretchr/testify/require"
+)
+
+// Tests for FixtureLoader Fixtures
+func TestFixtureLoader_Fixtures(t *testing.T) {
+	// arrange
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+		return path
+	}
+
+	t.Run("case 1 - success to load a yaml file in order", func(t *testing.T) {
+		// arrange
+		path := write("seed.yaml", `
+users:
+  - {id: 1, name: alice}
+tasks:
+  - {id: 1, title: task 1, user_id: 1}
+  - {id: 2, title: task 2, user_id: 1}
+`)
+		l := cases.NewFixtureLoader()
+
+		// act
+		fx, err := l.Fixtures(path)
+
+		// assert
+		require.NoError(t, err)
+		require.Equal(t, []cases.Fixture{
+			{Table: "users", Rows: []map[string]any{{"id": 1, "name": "alice"}}},
+			{Table: "tasks", Rows: []map[string]any{
+				{"id": 1, "title": "task 1", "user_id": 1},
+				{"id": 2, "title": "task 2", "user_id": 1},
+			}},
+		}, fx)
+	})
+
+	t.Run("case 2 - success to load a csv file named after its table", func(t *testing.T) {
+		// arrange
+		path := write("tasks.csv", "id,title,due\n1,task 1,2024-01-01\n2,\"task, 2\",\\N\n3,,\n")
+		l := cases.NewFixtureLoader()
+
+		// act
+		fx, err := l.Fixtures(path)
+
+		// assert
+		require.NoError(t, err)
+		require.Equal(t, []cases.Fixture{
+			{Table: "tasks", Rows: []map[string]any{
+				{"id": "1", "title": "task 1", "due": "2024-01-01"},
+				{"id": "2", "title": "task, 2", "due": nil},
+				{"id": "3", "title": "", "due": ""},
+			}},
+		}, fx)
+	})
+
+	t.Run("case 3 - error unsupported extension", func(t *testing.T) {
+		// arrange
+		path := write("seed.json", "{}")
+		l := cases.NewFixtureLoader()
+
+		// act
+		_, err := l.Fixtures(path)
+
+		// assert
+		require.ErrorIs(t, err, cases.ErrFixture)
+	})
+
+	t.Run("case 4 - error missing file", func(t *testing.T) {
+		// arrange
+		l := cases.NewFixtureLoader()
+
+		// act
+		_, err := l.Fixtures(filepath.Join(dir, "missing.yaml"))
+
+		// assert
+		require.ErrorIs(t, err, cases.ErrFixture)
+	})
+}
+
+// Tests for SplitSQL
+func TestSplitSQL(t *testing.T) {
+	t.Run("case 1 - statements with quotes and comments", func(t *testing.T) {
+		// act
+		stmts := cases.SplitSQL(`
+-- schema; not a statement
+CREATE TABLE tasks (id INTEGER, title TEXT);
+/* seed; data */
+INSERT INTO tasks VALUES (1, 'a;b'), (2, 'it''s');
+INSERT INTO "odd;name" VALUES (3, 'c')
+`, cases.DialectSQLite)
+
+		// assert
+		require.Equal(t, []string{
+			"CREATE TABLE tasks (id INTEGER, title TEXT)",
+			"INSERT INTO tasks VALUES (1, 'a;b'), (2, 'it''s')",
+			`INSERT INTO "odd;name" VALUES (3, 'c')`,
+		}, stmts)
+	})
+
+	t.Run("case 2 - postgres dollar-quoted bodies, # operators and standard strings", func(t *testing.T) {
+		// act
+		stmts := cases.SplitSQL(`
+CREATE FUNCTION touch() RETURNS trigger AS $$
+BEGIN
+  NEW.updated_at = now();
+  RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+DO $body$ BEGIN PERFORM 1; END $body$;
+PREPARE q AS SELECT $1::int; SELECT data #>> '{a,b}', 5 # 3 FROM t;
+INSERT INTO paths VALUES ('C:\'); SELECT 'a#b'
+`, cases.DialectPostgres)
+
+		// assert
+		require.Equal(t, []string{
+			"CREATE FUNCTION touch() RETURNS trigger AS $$\nBEGIN\n  NEW.updated_at = now();\n  RETURN NEW;\nEND;\n$$ LANGUAGE plpgsql",
+			"DO $body$ BEGIN PERFORM 1; END $body$",
+			"PREPARE q AS SELECT $1::int",
+			"SELECT data #>> '{a,b}', 5 # 3 FROM t",
+			`INSERT INTO paths VALUES ('C:\')`,
+			"SELECT 'a#b'",
+		}, stmts)
+	})
+
+	t.Run("case 3 - mysql # comments and backslash escapes", func(t *testing.T) {
+		// act
+		stmts := cases.SplitSQL(`
+# mysql comment; not a statement
+INSERT INTO tasks VALUES (1, 'it\'s; done'), (2, "a\";b");
+SELECT 'a#b'
+`, cases.DialectMySQL)
+
+		// assert
+		require.Equal(t, []string{
+			`INSERT INTO tasks VALUES (1, 'it\'s; done'), (2, "a\";b")`,
+			"SELECT 'a#b'",
+		}, stmts)
+	})
+}
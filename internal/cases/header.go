@@ -0,0 +1,77 @@
+package cases
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// DiffHeader returns the differences between the expected and the actual headers.
+// - names are case-insensitive, and reported in their canonical form as JSON pointers (e.g. /Content-Type)
+// - an expected value is either a string, a list of values or a matcher (e.g. {"$regex": "..."}, {"$present": true}, {"$absent": true})
+// - a string or matcher is compared against the values of the header joined by ", "
+// - a list is compared against each value of the header, in order
+// - with a subset match the actual headers that are not expected are ignored, with an exact match they are reported
+func DiffHeader(expected map[string]any, actual http.Header, mode MatchMode) (d []Difference, err error) {
+	df := differ{}
+	switch mode {
+	case "", MatchSubset:
+		df.subset = true
+	case MatchExact:
+	default:
+		err = fmt.Errorf("%w - %s", ErrUnknownMatchMode, mode)
+		return
+	}
+
+	// expected headers
+	seen := make(map[string]bool)
+	names := make([]string, 0, len(expected))
+	for k := range expected {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+	for _, k := range names {
+		name := http.CanonicalHeaderKey(k)
+		seen[name] = true
+		ve := expected[k]
+		values := actual.Values(name)
+		path := "/" + escapePointer(name)
+
+		// - missing
+		if len(values) == 0 {
+			if m, ok := asMatcher(ve); ok && m.absent() {
+				continue
+			}
+			d = append(d, Difference{Path: path, Kind: DiffRemoved, Expected: ve})
+			continue
+		}
+
+		// - present
+		var va any = strings.Join(values, ", ")
+		if _, ok := ve.([]any); ok {
+			list := make([]any, len(values))
+			for i, v := range values {
+				list[i] = v
+			}
+			va = list
+		}
+		d = df.diff(path, ve, va, d)
+	}
+
+	// unexpected headers
+	if !df.subset {
+		names = names[:0]
+		for k := range actual {
+			if !seen[http.CanonicalHeaderKey(k)] {
+				names = append(names, k)
+			}
+		}
+		sort.Strings(names)
+		for _, k := range names {
+			d = append(d, Difference{Path: "/" + escapePointer(http.CanonicalHeaderKey(k)), Kind: DiffAdded, Actual: strings.Join(actual[k], ", ")})
+		}
+	}
+
+	return
+}
@@ -0,0 +1,88 @@
+package cases_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/LNMMusic/tester/internal/cases"
+
+	"github.com/stretchr/testify/require"
+)
+
+// Tests for DiffHeader
+func TestDiffHeader(t *testing.T) {
+	// arrange
+	actual := http.Header{
+		"Content-Type": {"application/json"},
+		"X-Request-Id": {"0f8fad5b-d9cb-469f-a165-70867728950e"},
+		"Vary":         {"Origin", "Accept-Encoding"},
+	}
+
+	t.Run("case 1 - subset with case-insensitive names", func(t *testing.T) {
+		// act
+		d, err := cases.DiffHeader(map[string]any{
+			"content-type": []any{"application/json"},
+			"VARY":         "Origin, Accept-Encoding",
+		}, actual, "")
+
+		// assert
+		require.NoError(t, err)
+		require.Empty(t, d)
+	})
+
+	t.Run("case 2 - matchers", func(t *testing.T) {
+		// act
+		d, err := cases.DiffHeader(map[string]any{
+			"X-Request-Id":  map[string]any{"$regex": "^[0-9a-f-]{36}$"},
+			"Content-Type":  map[string]any{"$any": true},
+			"X-Powered-By":  map[string]any{"$absent": true},
+			"Cache-Control": map[string]any{"$any": true},
+			"Vary":          map[string]any{"$absent": true},
+			"X-Trace-Id":    map[string]any{"$present": false},
+			"Etag":          map[string]any{"$present": true},
+		}, actual, cases.MatchSubset)
+
+		// assert
+		require.NoError(t, err)
+		require.Equal(t, []cases.Difference{
+			{Path: "/Cache-Control", Kind: cases.DiffRemoved, Expected: map[string]any{"$any": true}},
+			{Path: "/Etag", Kind: cases.DiffRemoved, Expected: map[string]any{"$present": true}},
+			{Path: "/Vary", Kind: cases.DiffMatcher, Expected: map[string]any{"$absent": true}, Actual: "Origin, Accept-Encoding", Message: "is present"},
+		}, d)
+	})
+
+	t.Run("case 3 - list of values", func(t *testing.T) {
+		// act
+		d, err := cases.DiffHeader(map[string]any{
+			"Vary": []any{"Origin"},
+		}, actual, cases.MatchSubset)
+
+		// assert
+		require.NoError(t, err)
+		require.Equal(t, []cases.Difference{
+			{Path: "/Vary/1", Kind: cases.DiffAdded, Actual: "Accept-Encoding"},
+		}, d)
+	})
+
+	t.Run("case 4 - exact reports unexpected headers", func(t *testing.T) {
+		// act
+		d, err := cases.DiffHeader(map[string]any{
+			"Content-Type": "application/json",
+		}, actual, cases.MatchExact)
+
+		// assert
+		require.NoError(t, err)
+		require.Equal(t, []cases.Difference{
+			{Path: "/Vary", Kind: cases.DiffAdded, Actual: "Origin, Accept-Encoding"},
+			{Path: "/X-Request-Id", Kind: cases.DiffAdded, Actual: "0f8fad5b-d9cb-469f-a165-70867728950e"},
+		}, d)
+	})
+
+	t.Run("case 5 - error unknown mode", func(t *testing.T) {
+		// act
+		_, err := cases.DiffHeader(nil, actual, cases.MatchIgnoreOrder)
+
+		// assert
+		require.ErrorIs(t, err, cases.ErrUnknownMatchMode)
+	})
+}
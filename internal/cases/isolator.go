@@ -0,0 +1,134 @@
+package cases
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Isolator isolates the state of the database of each test case.
+// - Save is called before the set-up of a test case and Restore after its tear-down,
+//   so test cases only need to declare their seed data
+// - Save may wait for other test cases to be restored, until ctx is done
+type Isolator interface {
+	// Save saves the state of the database before a test case.
+	Save(ctx context.Context) (err error)
+	// Restore restores the state of the database after a test case.
+	Restore(ctx context.Context) (err error)
+}
+
+// Dialect is the SQL dialect of a database.
+type Dialect string
+
+const (
+	// DialectMySQL is the dialect of MySQL databases.
+	DialectMySQL Dialect = "mysql"
+	// DialectPostgres is the dialect of PostgreSQL databases.
+	DialectPostgres Dialect = "postgres"
+	// DialectSQLite is the dialect of SQLite databases.
+	DialectSQLite Dialect = "sqlite"
+)
+
+var (
+	// ErrIsolation is the error returned when the state of the database can not be saved or restored.
+	ErrIsolation = errors.New("isolation error")
+)
+
+// isolatorLock locks the database from Save to Restore.
+// - a channel is used instead of a mutex, so the wait for the lock can be canceled
+type isolatorLock chan struct{}
+
+// newIsolatorLock creates a new unlocked lock.
+func newIsolatorLock() isolatorLock {
+	return make(isolatorLock, 1)
+}
+
+// lock locks the database, waiting until it is unlocked or ctx is done.
+func (l isolatorLock) lock(ctx context.Context) (err error) {
+	select {
+	case l <- struct{}{}:
+	case <-ctx.Done():
+		err = fmt.Errorf("%w - waiting for the database: %v", ErrIsolation, context.Cause(ctx))
+	}
+	return
+}
+
+// unlock unlocks the database.
+func (l isolatorLock) unlock() {
+	<-l
+}
+
+// quote quotes a table or column name, which may be qualified by a schema (e.g. public.tasks).
+func (d Dialect) quote(name string) string {
+	q := `"`
+	if d == DialectMySQL {
+		q = "`"
+	}
+	parts := strings.Split(name, ".")
+	for i, p := range parts {
+		parts[i] = q + strings.ReplaceAll(p, q, q+q) + q
+	}
+	return strings.Join(parts, ".")
+}
+
+// placeholder returns the placeholder of the i-th parameter of a query (starting at 1).
+func (d Dialect) placeholder(i int) string {
+	if d == DialectPostgres {
+		return fmt.Sprintf("$%d", i)
+	}
+	return "?"
+}
+
+// truncate empties a set of tables and resets their identity counters.
+// - foreign key checks are disabled meanwhile when the dialect allows it, so the order of the tables does not matter
+func (d Dialect) truncate(ctx context.Context, conn *sql.Conn, tables []string) (err error) {
+	var queries []string
+	switch d {
+	case DialectPostgres:
+		quoted := make([]string, len(tables))
+		for i, t := range tables {
+			quoted[i] = d.quote(t)
+		}
+		queries = append(queries, fmt.Sprintf("TRUNCATE TABLE %s RESTART IDENTITY CASCADE", strings.Join(quoted, ", ")))
+	case DialectSQLite:
+		for _, t := range tables {
+			queries = append(queries, fmt.Sprintf("DELETE FROM %s", d.quote(t)))
+		}
+		// - sqlite_sequence only exists once a table with AUTOINCREMENT has been created
+		var n int
+		err = conn.QueryRowContext(ctx, "SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name = 'sqlite_sequence'").Scan(&n)
+		if err != nil {
+			return
+		}
+		if n > 0 {
+			for _, t := range tables {
+				queries = append(queries, fmt.Sprintf("DELETE FROM sqlite_sequence WHERE name = '%s'", strings.ReplaceAll(t, "'", "''")))
+			}
+		}
+	default:
+		_, err = conn.ExecContext(ctx, "SET FOREIGN_KEY_CHECKS = 0")
+		if err != nil {
+			return
+		}
+		// - re-enabled even if a truncate fails, as the connection goes back to the pool once closed
+		defer func() {
+			_, e := conn.ExecContext(ctx, "SET FOREIGN_KEY_CHECKS = 1")
+			if e != nil && err == nil {
+				err = e
+			}
+		}()
+		for _, t := range tables {
+			queries = append(queries, fmt.Sprintf("TRUNCATE TABLE %s", d.quote(t)))
+		}
+	}
+
+	for _, q := range queries {
+		_, err = conn.ExecContext(ctx, q)
+		if err != nil {
+			return
+		}
+	}
+	return
+}
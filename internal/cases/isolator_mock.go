@@ -0,0 +1,35 @@
+package cases
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// NewIsolatorMock creates a new isolator mock.
+func NewIsolatorMock() *IsolatorMock {
+	return &IsolatorMock{}
+}
+
+// IsolatorMock is a mock of isolator.
+type IsolatorMock struct {
+	mock.Mock
+}
+
+// Save mocks base method.
+func (m *IsolatorMock) Save(ctx context.Context) (err error) {
+	args := m.Called(ctx)
+
+	err = args.Error(0)
+
+	return
+}
+
+// Restore mocks base method.
+func (m *IsolatorMock) Restore(ctx context.Context) (err error) {
+	args := m.Called(ctx)
+
+	err = args.Error(0)
+
+	return
+}
@@ -0,0 +1,166 @@
+package cases
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+)
+
+// NewIsolatorSnapshot creates a new isolator that snapshots a set of tables.
+func NewIsolatorSnapshot(db *sql.DB, dialect Dialect, tables []string) *IsolatorSnapshot {
+	return &IsolatorSnapshot{
+		db:      db,
+		dialect: dialect,
+		tables:  tables,
+		lock:    newIsolatorLock(),
+	}
+}
+
+// IsolatorSnapshot is an isolator that snapshots the rows of a set of tables before the first test case
+// and restores them after each one.
+// - the database is locked from Save to Restore, so isolated test cases never run concurrently
+// - rows are restored in the order of the tables, so parent tables must be listed before their children
+type IsolatorSnapshot struct {
+	// db is the database to isolate.
+	db *sql.DB
+	// dialect is the SQL dialect of the database.
+	dialect Dialect
+	// tables is the set of tables to snapshot.
+	tables []string
+	// lock locks the database from Save to Restore.
+	lock isolatorLock
+	// snapshots is the set of snapshots of the tables, taken before the first test case.
+	snapshots []snapshot
+}
+
+// snapshot is the set of rows of a table.
+type snapshot struct {
+	// table is the name of the table.
+	table string
+	// columns is the set of columns of the table.
+	columns []string
+	// rows is the set of rows of the table.
+	rows [][]any
+}
+
+// Save locks the database, taking the snapshots if it is the first test case.
+func (i *IsolatorSnapshot) Save(ctx context.Context) (err error) {
+	err = i.lock.lock(ctx)
+	if err != nil {
+		return
+	}
+	if i.snapshots != nil {
+		return
+	}
+
+	snapshots := make([]snapshot, 0, len(i.tables))
+	for _, t := range i.tables {
+		var s snapshot
+		s, err = i.take(ctx, t)
+		if err != nil {
+			err = fmt.Errorf("%w - snapshot %s: %v", ErrIsolation, t, err)
+			i.lock.unlock()
+			return
+		}
+		snapshots = append(snapshots, s)
+	}
+	i.snapshots = snapshots
+	return
+}
+
+// Restore restores the snapshots and unlocks the database.
+func (i *IsolatorSnapshot) Restore(ctx context.Context) (err error) {
+	defer i.lock.unlock()
+
+	conn, err := i.db.Conn(ctx)
+	if err != nil {
+		err = fmt.Errorf("%w - %v", ErrIsolation, err)
+		return
+	}
+	defer conn.Close()
+
+	// - empty the tables
+	err = i.dialect.truncate(ctx, conn, i.tables)
+	if err != nil {
+		err = fmt.Errorf("%w - truncate: %v", ErrIsolation, err)
+		return
+	}
+	// - insert the rows back
+	for _, s := range i.snapshots {
+		err = i.restore(ctx, conn, s)
+		if err != nil {
+			err = fmt.Errorf("%w - restore %s: %v", ErrIsolation, s.table, err)
+			return
+		}
+	}
+	return
+}
+
+// take takes the snapshot of a table.
+func (i *IsolatorSnapshot) take(ctx context.Context, table string) (s snapshot, err error) {
+	rows, err := i.db.QueryContext(ctx, fmt.Sprintf("SELECT * FROM %s", i.dialect.quote(table)))
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+
+	s.table = table
+	s.columns, err = rows.Columns()
+	if err != nil {
+		return
+	}
+	for rows.Next() {
+		row := make([]any, len(s.columns))
+		ptrs := make([]any, len(s.columns))
+		for j := range row {
+			ptrs[j] = &row[j]
+		}
+		err = rows.Scan(ptrs...)
+		if err != nil {
+			return
+		}
+		s.rows = append(s.rows, row)
+	}
+	err = rows.Err()
+	return
+}
+
+// restore inserts the rows of a snapshot back into its table.
+// - postgres sequences are not advanced by explicit values, so they are moved past the restored ones
+func (i *IsolatorSnapshot) restore(ctx context.Context, conn *sql.Conn, s snapshot) (err error) {
+	if len(s.rows) > 0 {
+		columns := make([]string, len(s.columns))
+		params := make([]string, len(s.columns))
+		for j, c := range s.columns {
+			columns[j] = i.dialect.quote(c)
+			params[j] = i.dialect.placeholder(j + 1)
+		}
+		q := fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s)", i.dialect.quote(s.table), strings.Join(columns, ", "), strings.Join(params, ", "))
+		for _, row := range s.rows {
+			_, err = conn.ExecContext(ctx, q, row...)
+			if err != nil {
+				return
+			}
+		}
+	}
+
+	if i.dialect == DialectPostgres {
+		for _, c := range s.columns {
+			var seq sql.NullString
+			err = conn.QueryRowContext(ctx, "SELECT pg_get_serial_sequence($1, $2)", s.table, c).Scan(&seq)
+			if err != nil {
+				return
+			}
+			if !seq.Valid {
+				continue
+			}
+			q := fmt.Sprintf("SELECT setval($1, COALESCE((SELECT MAX(%s) FROM %s), 0) + 1, false)", i.dialect.quote(c), i.dialect.quote(s.table))
+			_, err = conn.ExecContext(ctx, q, seq.String)
+			if err != nil {
+				return
+			}
+		}
+	}
+	return
+}
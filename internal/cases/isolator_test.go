@@ -0,0 +1,198 @@
+package cases_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/LNMMusic/tester/internal/cases"
+
+	"github.com/stretchr/testify/require"
+	_ "modernc.org/sqlite"
+)
+
+// newTasksDB creates an in-memory database with a tasks table holding a single task.
+func newTasksDB(t *testing.T) *sql.DB {
+	db, err := sql.Open("sqlite", ":memory:")
+	require.NoError(t, err)
+	db.SetMaxOpenConns(1)
+	t.Cleanup(func() { db.Close() })
+
+	_, err = db.Exec("CREATE TABLE tasks (id INTEGER PRIMARY KEY AUTOINCREMENT, title TEXT NOT NULL)")
+	require.NoError(t, err)
+	_, err = db.Exec("INSERT INTO tasks (title) VALUES ('task 1')")
+	require.NoError(t, err)
+	return db
+}
+
+// tasks returns the titles of the tasks by id.
+func tasks(t *testing.T, db *sql.DB) map[int]string {
+	rows, err := db.Query("SELECT id, title FROM tasks")
+	require.NoError(t, err)
+	defer rows.Close()
+
+	ts := make(map[int]string)
+	for rows.Next() {
+		var id int
+		var title string
+		require.NoError(t, rows.Scan(&id, &title))
+		ts[id] = title
+	}
+	require.NoError(t, rows.Err())
+	return ts
+}
+
+// Tests for IsolatorTruncate
+func TestIsolatorTruncate(t *testing.T) {
+	t.Run("case 1 - tables are empty before and after each case", func(t *testing.T) {
+		// arrange
+		db := newTasksDB(t)
+		is := cases.NewIsolatorTruncate(db, cases.DialectSQLite, []string{"tasks"})
+
+		// act
+		err1 := is.Save(context.Background())
+		before := tasks(t, db)
+		_, err := db.Exec("INSERT INTO tasks (title) VALUES ('task 2')")
+		require.NoError(t, err)
+		during := tasks(t, db)
+		err2 := is.Restore(context.Background())
+		after := tasks(t, db)
+
+		// assert
+		require.NoError(t, err1)
+		require.NoError(t, err2)
+		require.Empty(t, before)
+		require.Equal(t, map[int]string{1: "task 2"}, during)
+		require.Empty(t, after)
+	})
+
+	t.Run("case 2 - error missing table", func(t *testing.T) {
+		// arrange
+		db := newTasksDB(t)
+		is := cases.NewIsolatorTruncate(db, cases.DialectSQLite, []string{"missing"})
+
+		// act
+		err := is.Save(context.Background())
+
+		// assert
+		require.ErrorIs(t, err, cases.ErrIsolation)
+	})
+
+	t.Run("case 3 - error waiting for a locked database until the context is done", func(t *testing.T) {
+		// arrange
+		db := newTasksDB(t)
+		is := cases.NewIsolatorTruncate(db, cases.DialectSQLite, []string{"tasks"})
+		require.NoError(t, is.Save(context.Background()))
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		// act
+		err1 := is.Save(ctx)
+		err2 := is.Restore(context.Background())
+		err3 := is.Save(context.Background())
+
+		// assert
+		require.ErrorIs(t, err1, cases.ErrIsolation)
+		require.EqualError(t, err1, "isolation error - waiting for the database: context deadline exceeded")
+		require.NoError(t, err2)
+		require.NoError(t, err3)
+	})
+}
+
+// Tests for IsolatorSnapshot
+func TestIsolatorSnapshot(t *testing.T) {
+	t.Run("case 1 - rows and identity are restored after each case", func(t *testing.T) {
+		// arrange
+		db := newTasksDB(t)
+		is := cases.NewIsolatorSnapshot(db, cases.DialectSQLite, []string{"tasks"})
+
+		// act
+		// - first case
+		err1 := is.Save(context.Background())
+		_, err := db.Exec("INSERT INTO tasks (title) VALUES ('task 2')")
+		require.NoError(t, err)
+		_, err = db.Exec("UPDATE tasks SET title = 'updated' WHERE id = 1")
+		require.NoError(t, err)
+		err2 := is.Restore(context.Background())
+		after := tasks(t, db)
+		// - second case
+		err3 := is.Save(context.Background())
+		_, err = db.Exec("INSERT INTO tasks (title) VALUES ('task 3')")
+		require.NoError(t, err)
+		during := tasks(t, db)
+		err4 := is.Restore(context.Background())
+
+		// assert
+		require.NoError(t, err1)
+		require.NoError(t, err2)
+		require.NoError(t, err3)
+		require.NoError(t, err4)
+		require.Equal(t, map[int]string{1: "task 1"}, after)
+		require.Equal(t, map[int]string{1: "task 1", 2: "task 3"}, during)
+	})
+
+	t.Run("case 2 - error missing table", func(t *testing.T) {
+		// arrange
+		db := newTasksDB(t)
+		is := cases.NewIsolatorSnapshot(db, cases.DialectSQLite, []string{"missing"})
+
+		// act
+		err := is.Save(context.Background())
+
+		// assert
+		require.ErrorIs(t, err, cases.ErrIsolation)
+	})
+}
+
+// Tests for IsolatorTruncate by dialect
+func TestIsolatorTruncate_Dialect(t *testing.T) {
+	tcs := []struct {
+		name    string
+		dialect cases.Dialect
+		queries []string
+	}{
+		{"case 1 - mysql", cases.DialectMySQL, []string{
+			"SET FOREIGN_KEY_CHECKS = 0",
+			"TRUNCATE TABLE `users`",
+			"TRUNCATE TABLE `public`.`tasks`",
+			"SET FOREIGN_KEY_CHECKS = 1",
+		}},
+		{"case 2 - postgres", cases.DialectPostgres, []string{
+			`TRUNCATE TABLE "users", "public"."tasks" RESTART IDENTITY CASCADE`,
+		}},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			// arrange
+			db, r := newRecorderDB(t, "")
+			is := cases.NewIsolatorTruncate(db, tc.dialect, []string{"users", "public.tasks"})
+
+			// act
+			err := is.Save(context.Background())
+
+			// assert
+			require.NoError(t, err)
+			require.Equal(t, tc.queries, r.Queries())
+			require.NoError(t, is.Restore(context.Background()))
+		})
+	}
+
+	t.Run("case 3 - mysql foreign key checks re-enabled after a failed truncate", func(t *testing.T) {
+		// arrange
+		db, r := newRecorderDB(t, "`public`.`tasks`")
+		is := cases.NewIsolatorTruncate(db, cases.DialectMySQL, []string{"users", "public.tasks"})
+
+		// act
+		err := is.Save(context.Background())
+
+		// assert
+		require.ErrorIs(t, err, cases.ErrIsolation)
+		require.Equal(t, []string{
+			"SET FOREIGN_KEY_CHECKS = 0",
+			"TRUNCATE TABLE `users`",
+			"TRUNCATE TABLE `public`.`tasks`",
+			"SET FOREIGN_KEY_CHECKS = 1",
+		}, r.Queries())
+	})
+}
@@ -0,0 +1,76 @@
+package cases
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+// NewIsolatorTruncate creates a new isolator that truncates a set of tables.
+func NewIsolatorTruncate(db *sql.DB, dialect Dialect, tables []string) *IsolatorTruncate {
+	return &IsolatorTruncate{
+		db:      db,
+		dialect: dialect,
+		tables:  tables,
+		lock:    newIsolatorLock(),
+	}
+}
+
+// IsolatorTruncate is an isolator that empties a set of tables before the first test case and after each one.
+// - the database is locked from Save to Restore, so isolated test cases never run concurrently
+type IsolatorTruncate struct {
+	// db is the database to isolate.
+	db *sql.DB
+	// dialect is the SQL dialect of the database.
+	dialect Dialect
+	// tables is the set of tables to truncate.
+	tables []string
+	// lock locks the database from Save to Restore.
+	lock isolatorLock
+	// clean is true once the tables have been truncated before the first test case.
+	clean bool
+}
+
+// Save locks the database, truncating the tables if it is the first test case.
+func (i *IsolatorTruncate) Save(ctx context.Context) (err error) {
+	err = i.lock.lock(ctx)
+	if err != nil {
+		return
+	}
+	if i.clean {
+		return
+	}
+
+	err = i.truncate(ctx)
+	if err != nil {
+		i.lock.unlock()
+		return
+	}
+	i.clean = true
+	return
+}
+
+// Restore truncates the tables and unlocks the database.
+func (i *IsolatorTruncate) Restore(ctx context.Context) (err error) {
+	defer i.lock.unlock()
+
+	err = i.truncate(ctx)
+	return
+}
+
+// truncate truncates the tables.
+func (i *IsolatorTruncate) truncate(ctx context.Context) (err error) {
+	conn, err := i.db.Conn(ctx)
+	if err != nil {
+		err = fmt.Errorf("%w - %v", ErrIsolation, err)
+		return
+	}
+	defer conn.Close()
+
+	err = i.dialect.truncate(ctx, conn, i.tables)
+	if err != nil {
+		err = fmt.Errorf("%w - truncate: %v", ErrIsolation, err)
+		return
+	}
+	return
+}
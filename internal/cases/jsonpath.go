@@ -0,0 +1,104 @@
+package cases
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var (
+	// ErrInvalidJSONPath is the error returned when a JSONPath expression is invalid.
+	ErrInvalidJSONPath = errors.New("invalid jsonpath")
+	// ErrJSONPathNotFound is the error returned when a JSONPath expression matches no value.
+	ErrJSONPathNotFound = errors.New("jsonpath not found")
+)
+
+// LookupJSONPath returns the value of a decoded JSON document at a JSONPath expression.
+// - only the subset of JSONPath that selects a single value is supported:
+//   the root `$`, child keys (`.key` or `['key']`) and array indexes (`[0]`, `[-1]` for the last one)
+func LookupJSONPath(doc any, path string) (v any, err error) {
+	if !strings.HasPrefix(path, "$") {
+		err = fmt.Errorf("%w - %s: must start with $", ErrInvalidJSONPath, path)
+		return
+	}
+
+	v = doc
+	rest := path[1:]
+	for rest != "" {
+		switch {
+		// - child key
+		case rest[0] == '.':
+			end := strings.IndexAny(rest[1:], ".[")
+			if end < 0 {
+				end = len(rest) - 1
+			}
+			key := rest[1 : end+1]
+			if key == "" {
+				err = fmt.Errorf("%w - %s: empty key", ErrInvalidJSONPath, path)
+				return
+			}
+			v, err = lookupKey(v, key, path)
+			rest = rest[end+1:]
+		// - quoted child key
+		case strings.HasPrefix(rest, "['"):
+			end := strings.Index(rest, "']")
+			if end < 0 {
+				err = fmt.Errorf("%w - %s: unclosed key", ErrInvalidJSONPath, path)
+				return
+			}
+			v, err = lookupKey(v, rest[2:end], path)
+			rest = rest[end+2:]
+		// - array index
+		case rest[0] == '[':
+			end := strings.Index(rest, "]")
+			if end < 0 {
+				err = fmt.Errorf("%w - %s: unclosed index", ErrInvalidJSONPath, path)
+				return
+			}
+			var i int
+			i, err = strconv.Atoi(rest[1:end])
+			if err != nil {
+				err = fmt.Errorf("%w - %s: invalid index %s", ErrInvalidJSONPath, path, rest[1:end])
+				return
+			}
+			v, err = lookupIndex(v, i, path)
+			rest = rest[end+1:]
+		default:
+			err = fmt.Errorf("%w - %s: unexpected %q", ErrInvalidJSONPath, path, rest)
+		}
+		if err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+// lookupKey returns the value of a key of an object.
+func lookupKey(v any, key, path string) (any, error) {
+	obj, ok := v.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("%w - %s: %s is not an object", ErrJSONPathNotFound, path, jsonType(v))
+	}
+	child, ok := obj[key]
+	if !ok {
+		return nil, fmt.Errorf("%w - %s: missing key %s", ErrJSONPathNotFound, path, key)
+	}
+	return child, nil
+}
+
+// lookupIndex returns the value of an index of an array.
+func lookupIndex(v any, i int, path string) (any, error) {
+	arr, ok := v.([]any)
+	if !ok {
+		return nil, fmt.Errorf("%w - %s: %s is not an array", ErrJSONPathNotFound, path, jsonType(v))
+	}
+	if i < 0 {
+		i += len(arr)
+	}
+	if i < 0 || i >= len(arr) {
+		return nil, fmt.Errorf("%w - %s: index out of range", ErrJSONPathNotFound, path)
+	}
+	return arr[i], nil
+}
@@ -0,0 +1,66 @@
+package cases_test
+
+import (
+	"testing"
+
+	"github.com/LNMMusic/tester/internal/cases"
+
+	"github.com/stretchr/testify/require"
+)
+
+// Tests for LookupJSONPath
+func TestLookupJSONPath(t *testing.T) {
+	// arrange
+	doc := map[string]any{
+		"data": map[string]any{
+			"id":   1.0,
+			"tags": []any{"a", "b", "c"},
+			"x.y":  true,
+		},
+	}
+
+	t.Run("case 1 - keys and indexes", func(t *testing.T) {
+		// act
+		id, err1 := cases.LookupJSONPath(doc, "$.data.id")
+		first, err2 := cases.LookupJSONPath(doc, "$.data.tags[0]")
+		last, err3 := cases.LookupJSONPath(doc, "$['data'].tags[-1]")
+		dotted, err4 := cases.LookupJSONPath(doc, "$.data['x.y']")
+		root, err5 := cases.LookupJSONPath(doc, "$")
+
+		// assert
+		require.NoError(t, err1)
+		require.NoError(t, err2)
+		require.NoError(t, err3)
+		require.NoError(t, err4)
+		require.NoError(t, err5)
+		require.Equal(t, 1.0, id)
+		require.Equal(t, "a", first)
+		require.Equal(t, "c", last)
+		require.Equal(t, true, dotted)
+		require.Equal(t, doc, root)
+	})
+
+	t.Run("case 2 - error not found", func(t *testing.T) {
+		// act
+		_, err1 := cases.LookupJSONPath(doc, "$.data.name")
+		_, err2 := cases.LookupJSONPath(doc, "$.data.tags[3]")
+		_, err3 := cases.LookupJSONPath(doc, "$.data.id.value")
+
+		// assert
+		require.ErrorIs(t, err1, cases.ErrJSONPathNotFound)
+		require.ErrorIs(t, err2, cases.ErrJSONPathNotFound)
+		require.ErrorIs(t, err3, cases.ErrJSONPathNotFound)
+	})
+
+	t.Run("case 3 - error invalid path", func(t *testing.T) {
+		// act
+		_, err1 := cases.LookupJSONPath(doc, "data.id")
+		_, err2 := cases.LookupJSONPath(doc, "$.data.tags[x]")
+		_, err3 := cases.LookupJSONPath(doc, "$.data..id")
+
+		// assert
+		require.ErrorIs(t, err1, cases.ErrInvalidJSONPath)
+		require.ErrorIs(t, err2, cases.ErrInvalidJSONPath)
+		require.ErrorIs(t, err3, cases.ErrInvalidJSONPath)
+	})
+}
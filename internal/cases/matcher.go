@@ -0,0 +1,192 @@
+package cases
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// A matcher is an object of the expected value whose keys are all operators (prefixed by `$`),
+// evaluated against the actual value instead of being compared as is. Operators are combined
+// with a logical and:
+// - $any: true, any value (the field must be present)
+// - $present: true, the field must be present with any value; false, the field must not be present
+// - $absent: true, the field must not be present (objects and headers only)
+// - $eq: value, equal to the value compared as is (e.g. to match objects with `$` keys)
+// - $regex: "pattern", a string matching the regular expression
+// - $type: "null" | "boolean" | "number" | "string" | "array" | "object"
+// - $gt, $gte, $lt, $lte: number, a number in range
+// - $len, $minLen, $maxLen: number, a string, array or object of that length
+type matcher map[string]any
+
+// asMatcher returns the expected value as a matcher, if it is one.
+func asMatcher(expected any) (m matcher, ok bool) {
+	obj, ok := expected.(map[string]any)
+	if !ok || len(obj) == 0 {
+		return nil, false
+	}
+	for k := range obj {
+		if !strings.HasPrefix(k, "$") {
+			return nil, false
+		}
+	}
+	return matcher(obj), true
+}
+
+// absent returns true if the matcher requires the field not to be present.
+func (m matcher) absent() bool {
+	if v, ok := m["$present"].(bool); ok && !v {
+		return true
+	}
+	v, ok := m["$absent"].(bool)
+	return ok && v
+}
+
+// match evaluates the matcher against a present actual value.
+// - msg describes the first operator that is not satisfied
+func (m matcher) match(actual any) (msg string, ok bool) {
+	for _, op := range m.operators() {
+		arg := m[op]
+		switch op {
+		case "$any":
+			// any present value
+		case "$absent":
+			if b, _ := arg.(bool); b {
+				return "is present", false
+			}
+		case "$present":
+			b, isBool := arg.(bool)
+			if !isBool {
+				return fmt.Sprintf("invalid $present %s", compactJSON(arg)), false
+			}
+			if !b {
+				return "is present", false
+			}
+		case "$eq":
+			if len(differ{literal: true}.diff("", arg, actual, nil)) > 0 {
+				return fmt.Sprintf("is not equal to %s", compactJSON(arg)), false
+			}
+		case "$regex":
+			msg, ok = matchRegex(arg, actual)
+			if !ok {
+				return
+			}
+		case "$type":
+			if t, _ := arg.(string); jsonType(actual) != t {
+				return fmt.Sprintf("is of type %s, not %v", jsonType(actual), arg), false
+			}
+		case "$gt", "$gte", "$lt", "$lte":
+			msg, ok = matchRange(op, arg, actual)
+			if !ok {
+				return
+			}
+		case "$len", "$minLen", "$maxLen":
+			msg, ok = matchLen(op, arg, actual)
+			if !ok {
+				return
+			}
+		default:
+			return fmt.Sprintf("unknown matcher %s", op), false
+		}
+	}
+	return "", true
+}
+
+// operators returns the operators of the matcher in sorted order.
+func (m matcher) operators() (ops []string) {
+	for op := range m {
+		ops = append(ops, op)
+	}
+	sort.Strings(ops)
+	return
+}
+
+// regexps caches the compiled regular expressions of the matchers.
+var regexps sync.Map
+
+// matchRegex matches a string against a regular expression.
+func matchRegex(pattern, actual any) (msg string, ok bool) {
+	p, isString := pattern.(string)
+	if !isString {
+		return fmt.Sprintf("invalid $regex %s", compactJSON(pattern)), false
+	}
+	s, isString := actual.(string)
+	if !isString {
+		return fmt.Sprintf("is not a string to match $regex %q", p), false
+	}
+
+	re, cached := regexps.Load(p)
+	if !cached {
+		compiled, err := regexp.Compile(p)
+		if err != nil {
+			return fmt.Sprintf("invalid $regex %q: %v", p, err), false
+		}
+		re, _ = regexps.LoadOrStore(p, compiled)
+	}
+	if !re.(*regexp.Regexp).MatchString(s) {
+		return fmt.Sprintf("does not match $regex %q", p), false
+	}
+	return "", true
+}
+
+// matchRange matches a number against a bound.
+func matchRange(op string, bound, actual any) (msg string, ok bool) {
+	b, isNumber := toFloat(bound)
+	if !isNumber {
+		return fmt.Sprintf("invalid %s %s", op, compactJSON(bound)), false
+	}
+	a, isNumber := toFloat(actual)
+	if !isNumber {
+		return fmt.Sprintf("is not a number to match %s %v", op, b), false
+	}
+
+	switch op {
+	case "$gt":
+		ok = a > b
+	case "$gte":
+		ok = a >= b
+	case "$lt":
+		ok = a < b
+	case "$lte":
+		ok = a <= b
+	}
+	if !ok {
+		msg = fmt.Sprintf("does not match %s %v", op, b)
+	}
+	return
+}
+
+// matchLen matches the length of a string, array or object against a bound.
+func matchLen(op string, bound, actual any) (msg string, ok bool) {
+	b, isNumber := toFloat(bound)
+	if !isNumber {
+		return fmt.Sprintf("invalid %s %s", op, compactJSON(bound)), false
+	}
+
+	var n int
+	switch a := actual.(type) {
+	case string:
+		n = len([]rune(a))
+	case []any:
+		n = len(a)
+	case map[string]any:
+		n = len(a)
+	default:
+		return fmt.Sprintf("has no length to match %s %v", op, b), false
+	}
+
+	switch op {
+	case "$len":
+		ok = float64(n) == b
+	case "$minLen":
+		ok = float64(n) >= b
+	case "$maxLen":
+		ok = float64(n) <= b
+	}
+	if !ok {
+		msg = fmt.Sprintf("has length %d, does not match %s %v", n, op, b)
+	}
+	return
+}
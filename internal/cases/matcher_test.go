@@ -0,0 +1,95 @@
+package cases_test
+
+import (
+	"testing"
+
+	"github.com/LNMMusic/tester/internal/cases"
+
+	"github.com/stretchr/testify/require"
+)
+
+// Tests for the matchers of the expected values
+func TestDiff_Matchers(t *testing.T) {
+	type input struct {
+		expected any
+		actual   any
+	}
+	type output struct {
+		message string
+	}
+	type testCase struct {
+		name   string
+		input  input
+		output output
+	}
+
+	tests := []testCase{
+		{name: "$any", input: input{expected: map[string]any{"$any": true}, actual: []any{1.0}}},
+		{name: "$present - match", input: input{expected: map[string]any{"$present": true}, actual: nil}},
+		{name: "$present false - no match", input: input{expected: map[string]any{"$present": false}, actual: "secret"}, output: output{message: "is present"}},
+		{name: "$present - invalid", input: input{expected: map[string]any{"$present": "yes"}, actual: 1.0}, output: output{message: `invalid $present "yes"`}},
+		{name: "$regex - match", input: input{expected: map[string]any{"$regex": "^[0-9a-f-]{36}$"}, actual: "0f8fad5b-d9cb-469f-a165-70867728950e"}},
+		{name: "$regex - no match", input: input{expected: map[string]any{"$regex": "^[0-9]+$"}, actual: "abc"}, output: output{message: `does not match $regex "^[0-9]+$"`}},
+		{name: "$regex - not a string", input: input{expected: map[string]any{"$regex": "^[0-9]+$"}, actual: 1.0}, output: output{message: `is not a string to match $regex "^[0-9]+$"`}},
+		{name: "$regex - invalid", input: input{expected: map[string]any{"$regex": "("}, actual: "("}, output: output{message: "invalid $regex \"(\": error parsing regexp: missing closing ): `(`"}},
+		{name: "$type - match", input: input{expected: map[string]any{"$type": "number"}, actual: 1.0}},
+		{name: "$type - no match", input: input{expected: map[string]any{"$type": "number"}, actual: "1"}, output: output{message: "is of type string, not number"}},
+		{name: "$gt and $lte - match", input: input{expected: map[string]any{"$gt": 0.0, "$lte": 10.0}, actual: 10.0}},
+		{name: "$gt - no match", input: input{expected: map[string]any{"$gt": 0.0}, actual: 0.0}, output: output{message: "does not match $gt 0"}},
+		{name: "$lt - not a number", input: input{expected: map[string]any{"$lt": 1.0}, actual: "0"}, output: output{message: "is not a number to match $lt 1"}},
+		{name: "$len - match", input: input{expected: map[string]any{"$len": 2.0}, actual: []any{1.0, 2.0}}},
+		{name: "$minLen - no match", input: input{expected: map[string]any{"$minLen": 3.0}, actual: "ab"}, output: output{message: "has length 2, does not match $minLen 3"}},
+		{name: "$maxLen - no length", input: input{expected: map[string]any{"$maxLen": 3.0}, actual: true}, output: output{message: "has no length to match $maxLen 3"}},
+		{name: "$eq - match objects with $ keys", input: input{expected: map[string]any{"$eq": map[string]any{"$ref": "a"}}, actual: map[string]any{"$ref": "a"}}},
+		{name: "unknown", input: input{expected: map[string]any{"$foo": true}, actual: 1.0}, output: output{message: "unknown matcher $foo"}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// act
+			d := cases.Diff(tc.input.expected, tc.input.actual)
+
+			// assert
+			if tc.output.message == "" {
+				require.Empty(t, d)
+				return
+			}
+			require.Equal(t, []cases.Difference{
+				{Path: "", Kind: cases.DiffMatcher, Expected: tc.input.expected, Actual: tc.input.actual, Message: tc.output.message},
+			}, d)
+		})
+	}
+
+	t.Run("nested matchers, $present and $absent", func(t *testing.T) {
+		// arrange
+		expected := map[string]any{
+			"data": map[string]any{
+				"id":         map[string]any{"$type": "number"},
+				"created_at": map[string]any{"$any": true},
+				"password":   map[string]any{"$absent": true},
+				"deleted_at": map[string]any{"$absent": true},
+				"title":      map[string]any{"$present": true},
+				"updated_at": map[string]any{"$present": true},
+				"token":      map[string]any{"$present": false},
+			},
+		}
+		actual := map[string]any{
+			"data": map[string]any{
+				"id":       "1",
+				"password": "secret",
+				"title":    "task 1",
+			},
+		}
+
+		// act
+		d := cases.Diff(expected, actual)
+
+		// assert
+		require.Equal(t, []cases.Difference{
+			{Path: "/data/created_at", Kind: cases.DiffRemoved, Expected: map[string]any{"$any": true}},
+			{Path: "/data/id", Kind: cases.DiffMatcher, Expected: map[string]any{"$type": "number"}, Actual: "1", Message: "is of type string, not number"},
+			{Path: "/data/password", Kind: cases.DiffMatcher, Expected: map[string]any{"$absent": true}, Actual: "secret", Message: "is present"},
+			{Path: "/data/updated_at", Kind: cases.DiffRemoved, Expected: map[string]any{"$present": true}},
+		}, d)
+	})
+}
@@ -1,8 +1,12 @@
 package cases
 
 import (
+	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
+	"time"
 )
 
 // Database is a database to run the test case against.
@@ -11,6 +15,12 @@ type Database struct {
 	SetUp []string `json:"set_up"`
 	// TearDown is the set of tear down queries to run after the test case.
 	TearDown []string `json:"tear_down"`
+	// SQLFiles is the set of sql scripts to run before the fixtures and the set up queries.
+	// - relative paths are relative to the file of the test case
+	SQLFiles []string `json:"sql_files"`
+	// Fixtures is the set of YAML or CSV files of rows to insert before the set up queries.
+	// - relative paths are relative to the file of the test case
+	Fixtures []string `json:"fixtures"`
 }
 
 // Request is a request to make for the test case.
@@ -23,7 +33,10 @@ type Request struct {
 	Query map[string]string `json:"query"`
 	// Body is the body to use for the request.
 	Body any `json:"body"`
-	// Header is the set of headers to use for the request.
+	// BodyType is the type of the body (json by default, form, multipart, text or binary).
+	// - the matching Content-Type is set unless the header is given
+	BodyType BodyType `json:"body_type,omitempty"`
+	// Header is the set of headers to use for the request, each with one or more values (a Host header overrides the host).
 	Header http.Header `json:"header"`
 }
 
@@ -33,20 +46,115 @@ type Response struct {
 	Code int `json:"code"`
 	// Body is the expected body of the response.
 	Body any `json:"body"`
+	// BodyType is the type used to compare the body (json, text, xml, binary or empty).
+	// - picked from the Content-Type of the response by default
+	BodyType BodyType `json:"body_type,omitempty"`
 	// Header is the expected set of headers of the response.
-	Header http.Header `json:"header"`
+	// - values are either a string, a list of strings or a matcher (e.g. {"$absent": true})
+	Header map[string]any `json:"header"`
+	// Match is the mode used to match the expected body (exact, subset or ignore_order).
+	// - empty to use the default mode of the reporter
+	Match MatchMode `json:"match,omitempty"`
+	// HeaderMatch is the mode used to match the expected headers (subset by default, or exact).
+	HeaderMatch MatchMode `json:"header_match,omitempty"`
+}
+
+// DatabaseExpectation is the expected result of a query run on the database after the request.
+type DatabaseExpectation struct {
+	// Query is the query to run.
+	Query string `json:"query"`
+	// Rows is the set of expected rows, by column.
+	// - values may be matchers, as in the expected bodies
+	Rows []map[string]any `json:"rows"`
+	// Match is the mode used to match the rows (exact by default, subset or ignore_order).
+	Match MatchMode `json:"match,omitempty"`
+}
+
+// Step is a step of a scenario: a request, its expected response and the values it captures.
+type Step struct {
+	// Name is the name of the step.
+	Name string `json:"step_name"`
+	// Input
+	Request Request `json:"request"`
+	// Output
+	Response Response `json:"response"`
+	// ExpectDatabase is the set of expected results of queries run after the request.
+	ExpectDatabase []DatabaseExpectation `json:"expect_database"`
+	// Capture is the set of values of the response captured into variables for the following steps.
+	Capture Capture `json:"capture"`
+}
+
+// Scenario is an ordered set of steps run within the set-up and tear-down of a single test case.
+type Scenario struct {
+	// Steps is the set of steps, run in order.
+	Steps []Step `json:"steps"`
 }
 
 // Case is a test case.
 type Case struct {
 	// Name is the name of the test case.
 	Name string `json:"case_name"`
+	// File is the file the test case was read from.
+	File string `json:"-"`
+	// Skip is true if the test case must not be run.
+	Skip bool `json:"skip"`
+	// Serial is true if the test case must not run concurrently with any other test case.
+	Serial bool `json:"serial"`
+	// Group is the name of the group of test cases that share state.
+	// - test cases of the same group run one at a time, in the order they were read
+	// - test cases without a group that capture or reference variables are grouped implicitly,
+	//   so a variable is captured before it is referenced; a variable must be captured and referenced
+	//   within the same group otherwise
+	Group string `json:"group"`
 	// Arrange
 	Database `json:"database"`
 	// Input
 	Request `json:"request"`
 	// Output
 	Response `json:"response"`
+	// ExpectDatabase is the set of expected results of queries run after the request.
+	ExpectDatabase []DatabaseExpectation `json:"expect_database"`
+	// Capture is the set of values of the response captured into variables for the following test cases.
+	// - variables are referenced as {{ name }} in the request, expected response and queries
+	Capture Capture `json:"capture"`
+	// Scenario is the set of steps to run instead of the single request, if any.
+	// - the steps share the set-up and tear-down of the test case
+	// - the database expectations and captures of the test case apply once every step passed,
+	//   captures taken from the response of the last step
+	Scenario Scenario `json:"scenario"`
+	// Timeout is the maximum time to run the test case, overriding the default one (e.g. "5s").
+	// - the tear-down still runs once the test case timed out
+	Timeout Duration `json:"timeout,omitempty"`
+}
+
+var (
+	// ErrInvalidDuration is the error returned when a duration is not a string such as "5s" or "250ms".
+	ErrInvalidDuration = errors.New("invalid duration")
+)
+
+// Duration is a duration written as a string in the test cases (e.g. "5s", "250ms").
+type Duration time.Duration
+
+// UnmarshalJSON decodes a duration from a string.
+func (d *Duration) UnmarshalJSON(b []byte) (err error) {
+	var s string
+	err = json.Unmarshal(b, &s)
+	if err != nil {
+		err = fmt.Errorf("%w - %s", ErrInvalidDuration, b)
+		return
+	}
+	v, err := time.ParseDuration(s)
+	if err != nil {
+		err = fmt.Errorf("%w - %v", ErrInvalidDuration, err)
+		return
+	}
+	*d = Duration(v)
+	return
+}
+
+// MarshalJSON encodes a duration as a string.
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(d).String())
 }
 
 var (
@@ -58,4 +166,46 @@ var (
 type Reader interface {
 	// Read reads the next test case.
 	Read() (c Case, err error)
-}
\ No newline at end of file
+}
+
+// StreamReader is a reader of test cases that streams them concurrently.
+type StreamReader interface {
+	Reader
+	// Stream streams the test cases until the source is exhausted or ctx is done.
+	Stream(ctx context.Context)
+}
+
+// CaseErr is a test case with an error.
+type CaseErr struct {
+	// Case is the test case.
+	Case Case
+	// Err is the error.
+	Err error
+}
+
+// receive receives the next test case of the channel of a stream.
+func receive(ch chan CaseErr) (c Case, err error) {
+	// fetch the next test case
+	ce, ok := <-ch
+	if !ok {
+		err = ErrEndOfLine
+		return
+	}
+	if ce.Err != nil {
+		err = ce.Err
+		return
+	}
+
+	c = ce.Case
+	return
+}
+
+// send sends a test case to the channel, unless ctx is done before it is read.
+func send(ctx context.Context, ch chan CaseErr, ce CaseErr) (err error) {
+	select {
+	case ch <- ce:
+	case <-ctx.Done():
+		err = context.Cause(ctx)
+	}
+	return
+}
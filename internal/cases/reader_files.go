@@ -0,0 +1,190 @@
+package cases
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	// ErrNoFiles is the error returned when no file matches the patterns.
+	ErrNoFiles = errors.New("no case files found")
+	// ErrOpenFile is the error returned when a file can not be opened.
+	ErrOpenFile = errors.New("open case file error")
+)
+
+// NewReaderFiles creates a new reader of test cases from a set of files.
+// - the format of each file is chosen by its extension (.yaml and .yml for YAML, JSON otherwise)
+func NewReaderFiles(files []string, ch chan CaseErr) *ReaderFiles {
+	return &ReaderFiles{
+		files: files,
+		ch:    ch,
+	}
+}
+
+// ReaderFiles is a reader of test cases from a set of files.
+type ReaderFiles struct {
+	// files is the set of files to read, in order.
+	files []string
+	// ch is the channel of test cases.
+	ch chan CaseErr
+}
+
+// Read reads the next test case.
+func (r *ReaderFiles) Read() (c Case, err error) {
+	c, err = receive(r.ch)
+	return
+}
+
+// Stream is a concurrent reader of test cases
+// - files are read one after another, stopping at the first error
+// - it stops once ctx is done, so it does not block on cases that are never read
+// - the cancellation of ctx is not sent as an error, the interruption is reported by the consumer
+func (r *ReaderFiles) Stream(ctx context.Context) {
+	// close the channel at the end
+	defer close(r.ch)
+
+	for _, file := range r.files {
+		err := r.stream(ctx, file)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			_ = send(ctx, r.ch, CaseErr{Err: fmt.Errorf("%s: %w", file, err)})
+			return
+		}
+	}
+}
+
+// stream sends the test cases of a file to the channel.
+func (r *ReaderFiles) stream(ctx context.Context, file string) (err error) {
+	f, err := os.Open(file)
+	if err != nil {
+		err = fmt.Errorf("%w - %s", ErrOpenFile, err.Error())
+		return
+	}
+	defer f.Close()
+
+	switch filepath.Ext(file) {
+	case ".yaml", ".yml":
+		err = NewReaderYAML(yaml.NewDecoder(f), r.ch).stream(ctx, file)
+	default:
+		err = NewReaderJSON(json.NewDecoder(f), r.ch).stream(ctx, file)
+	}
+	return
+}
+
+// caseFileExts is the set of extensions of case files looked up in directories.
+var caseFileExts = map[string]bool{".json": true, ".yaml": true, ".yml": true}
+
+// MatchFiles returns the sorted set of case files matching the patterns.
+// - a pattern is either a file, a directory (read recursively) or a glob
+// - globs support `**` to match any number of directories (e.g. cases/**/*.json)
+func MatchFiles(patterns ...string) (files []string, err error) {
+	set := make(map[string]bool)
+	for _, p := range patterns {
+		var matches []string
+		matches, err = matchFiles(p)
+		if err != nil {
+			return
+		}
+		for _, m := range matches {
+			set[m] = true
+		}
+	}
+	if len(set) == 0 {
+		err = fmt.Errorf("%w - %s", ErrNoFiles, strings.Join(patterns, ", "))
+		return
+	}
+
+	for f := range set {
+		files = append(files, f)
+	}
+	sort.Strings(files)
+	return
+}
+
+// matchFiles returns the case files matching a single pattern.
+func matchFiles(pattern string) (files []string, err error) {
+	// glob
+	if strings.ContainsAny(pattern, "*?[") {
+		// - root: directory before the first meta character
+		root := pattern
+		for strings.ContainsAny(root, "*?[") {
+			root = filepath.Dir(root)
+		}
+		pattern = filepath.ToSlash(filepath.Clean(pattern))
+		err = filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if !d.IsDir() && matchGlob(pattern, filepath.ToSlash(filepath.Clean(path))) {
+				files = append(files, path)
+			}
+			return nil
+		})
+		return
+	}
+
+	// file
+	info, err := os.Stat(pattern)
+	if err != nil {
+		err = fmt.Errorf("%w - %s", ErrOpenFile, err.Error())
+		return
+	}
+	if !info.IsDir() {
+		files = append(files, pattern)
+		return
+	}
+
+	// directory
+	err = filepath.WalkDir(pattern, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() && caseFileExts[filepath.Ext(path)] {
+			files = append(files, path)
+		}
+		return nil
+	})
+	return
+}
+
+// matchGlob reports whether a slash separated path matches a glob pattern.
+// - `**` matches zero or more path segments, the rest follows filepath.Match
+func matchGlob(pattern, path string) bool {
+	return matchSegments(strings.Split(pattern, "/"), strings.Split(path, "/"))
+}
+
+// matchSegments matches the segments of a path against the segments of a pattern.
+func matchSegments(pattern, path []string) bool {
+	if len(pattern) == 0 {
+		return len(path) == 0
+	}
+
+	if pattern[0] == "**" {
+		for i := 0; i <= len(path); i++ {
+			if matchSegments(pattern[1:], path[i:]) {
+				return true
+			}
+		}
+		return false
+	}
+
+	if len(path) == 0 {
+		return false
+	}
+	ok, err := filepath.Match(pattern[0], path[0])
+	if err != nil || !ok {
+		return false
+	}
+	return matchSegments(pattern[1:], path[1:])
+}
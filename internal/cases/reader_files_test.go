@@ -0,0 +1,165 @@
+package cases_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/LNMMusic/tester/internal/cases"
+
+	"github.com/stretchr/testify/require"
+)
+
+// writeFiles writes a set of files under a directory.
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	}
+}
+
+// Tests for MatchFiles
+func TestMatchFiles(t *testing.T) {
+	// arrange
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"tasks/get.json":         "[]",
+		"tasks/create.yaml":      "",
+		"users/nested/list.json": "[]",
+		"users/readme.md":        "",
+		"auth.json":              "[]",
+	})
+
+	t.Run("case 1 - success to match a file", func(t *testing.T) {
+		// act
+		files, err := cases.MatchFiles(filepath.Join(dir, "auth.json"))
+
+		// assert
+		require.NoError(t, err)
+		require.Equal(t, []string{filepath.Join(dir, "auth.json")}, files)
+	})
+
+	t.Run("case 2 - success to match a directory recursively", func(t *testing.T) {
+		// act
+		files, err := cases.MatchFiles(dir)
+
+		// assert
+		require.NoError(t, err)
+		require.Equal(t, []string{
+			filepath.Join(dir, "auth.json"),
+			filepath.Join(dir, "tasks/create.yaml"),
+			filepath.Join(dir, "tasks/get.json"),
+			filepath.Join(dir, "users/nested/list.json"),
+		}, files)
+	})
+
+	t.Run("case 3 - success to match a glob with double star", func(t *testing.T) {
+		// act
+		files, err := cases.MatchFiles(filepath.Join(dir, "**/*.json"))
+
+		// assert
+		require.NoError(t, err)
+		require.Equal(t, []string{
+			filepath.Join(dir, "auth.json"),
+			filepath.Join(dir, "tasks/get.json"),
+			filepath.Join(dir, "users/nested/list.json"),
+		}, files)
+	})
+
+	t.Run("case 4 - success to match several patterns without duplicates", func(t *testing.T) {
+		// act
+		files, err := cases.MatchFiles(filepath.Join(dir, "tasks/*"), filepath.Join(dir, "tasks/get.json"))
+
+		// assert
+		require.NoError(t, err)
+		require.Equal(t, []string{
+			filepath.Join(dir, "tasks/create.yaml"),
+			filepath.Join(dir, "tasks/get.json"),
+		}, files)
+	})
+
+	t.Run("case 5 - error no files matched", func(t *testing.T) {
+		// act
+		files, err := cases.MatchFiles(filepath.Join(dir, "**/*.xml"))
+
+		// assert
+		require.ErrorIs(t, err, cases.ErrNoFiles)
+		require.Nil(t, files)
+	})
+
+	t.Run("case 6 - error file not found", func(t *testing.T) {
+		// act
+		files, err := cases.MatchFiles(filepath.Join(dir, "missing.json"))
+
+		// assert
+		require.ErrorIs(t, err, cases.ErrOpenFile)
+		require.Nil(t, files)
+	})
+}
+
+// Tests for ReaderFiles Stream
+func TestReaderFiles_Stream(t *testing.T) {
+	t.Run("case 1 - success to read cases of several files", func(t *testing.T) {
+		// arrange
+		dir := t.TempDir()
+		writeFiles(t, dir, map[string]string{
+			"a.json": `[{"case_name":"case 1"},{"case_name":"case 2"}]`,
+			"b.yaml": "case_name: case 3\n",
+		})
+		ch := make(chan cases.CaseErr)
+		rd := cases.NewReaderFiles([]string{filepath.Join(dir, "a.json"), filepath.Join(dir, "b.yaml")}, ch)
+
+		// act
+		go rd.Stream(context.Background())
+		c1, err1 := rd.Read()
+		c2, err2 := rd.Read()
+		c3, err3 := rd.Read()
+		_, err4 := rd.Read()
+
+		// assert
+		require.NoError(t, err1)
+		require.Equal(t, cases.Case{Name: "case 1", File: filepath.Join(dir, "a.json")}, c1)
+		require.NoError(t, err2)
+		require.Equal(t, cases.Case{Name: "case 2", File: filepath.Join(dir, "a.json")}, c2)
+		require.NoError(t, err3)
+		require.Equal(t, cases.Case{Name: "case 3", File: filepath.Join(dir, "b.yaml")}, c3)
+		require.ErrorIs(t, err4, cases.ErrEndOfLine)
+	})
+
+	t.Run("case 2 - error malformed file", func(t *testing.T) {
+		// arrange
+		dir := t.TempDir()
+		writeFiles(t, dir, map[string]string{
+			"a.json": `[invalid json]`,
+		})
+		ch := make(chan cases.CaseErr)
+		rd := cases.NewReaderFiles([]string{filepath.Join(dir, "a.json")}, ch)
+
+		// act
+		go rd.Stream(context.Background())
+		_, err1 := rd.Read()
+		_, err2 := rd.Read()
+
+		// assert
+		require.ErrorIs(t, err1, cases.ErrMalformedJSON)
+		require.ErrorContains(t, err1, filepath.Join(dir, "a.json"))
+		require.ErrorIs(t, err2, cases.ErrEndOfLine)
+	})
+
+	t.Run("case 3 - error missing file", func(t *testing.T) {
+		// arrange
+		ch := make(chan cases.CaseErr)
+		rd := cases.NewReaderFiles([]string{"missing.json"}, ch)
+
+		// act
+		go rd.Stream(context.Background())
+		_, err1 := rd.Read()
+		_, err2 := rd.Read()
+
+		// assert
+		require.ErrorIs(t, err1, cases.ErrOpenFile)
+		require.ErrorIs(t, err2, cases.ErrEndOfLine)
+	})
+}
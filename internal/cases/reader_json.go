@@ -1,6 +1,7 @@
 package cases
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -21,14 +22,6 @@ func NewReaderJSON(decoder *json.Decoder, ch chan CaseErr) *ReaderJSON {
 	}
 }
 
-// CaseErr is a test case with an error.
-type CaseErr struct {
-	// Case is the test case.
-	Case Case
-	// Err is the error.
-	Err error
-}
-
 // ReaderJSON is a reader of test cases in JSON format.
 type ReaderJSON struct {
 	// decoder is the JSON decoder to use.
@@ -39,30 +32,29 @@ type ReaderJSON struct {
 
 // Read reads the next test case.
 func (r *ReaderJSON) Read() (c Case, err error) {
-	// fetch the next test case
-	ce, ok := <-r.ch
-	if !ok {
-		err = ErrEndOfLine
-		return
-	}
-	if ce.Err != nil {
-		err = ce.Err
-		return
-	}
-
-	c = ce.Case
+	c, err = receive(r.ch)
 	return
 }
 
 // Stream is a concurrent reader of test cases
-func (r *ReaderJSON) Stream() {
+// - it stops once ctx is done, so it does not block on cases that are never read
+// - the cancellation of ctx is not sent as an error, the interruption is reported by the consumer
+func (r *ReaderJSON) Stream(ctx context.Context) {
 	// close the channel at the end
 	defer close(r.ch)
 
+	err := r.stream(ctx, "")
+	if err != nil && ctx.Err() == nil {
+		_ = send(ctx, r.ch, CaseErr{Err: err})
+	}
+}
+
+// stream sends the test cases of the decoder to the channel, tagged with their source file.
+func (r *ReaderJSON) stream(ctx context.Context, file string) (err error) {
 	// read the opening bracket of the array
-	_, err := r.decoder.Token()
+	_, err = r.decoder.Token()
 	if err != nil {
-		r.ch <- CaseErr{Err: fmt.Errorf("%w - %s", ErrInvalidToken, err.Error())}
+		err = fmt.Errorf("%w - %s", ErrInvalidToken, err.Error())
 		return
 	}
 
@@ -71,10 +63,16 @@ func (r *ReaderJSON) Stream() {
 		var c Case
 		err = r.decoder.Decode(&c)
 		if err != nil {
-			r.ch <- CaseErr{Err: fmt.Errorf("%w - %s", ErrMalformedJSON, err.Error())}
+			err = fmt.Errorf("%w - %s", ErrMalformedJSON, err.Error())
 			return
 		}
+		c.File = file
 
-		r.ch <- CaseErr{Case: c}
+		err = send(ctx, r.ch, CaseErr{Case: c})
+		if err != nil {
+			return
+		}
 	}
-}
\ No newline at end of file
+
+	return
+}
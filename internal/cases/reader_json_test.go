@@ -1,12 +1,14 @@
 package cases_test
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/LNMMusic/tester/internal/cases"
 
@@ -27,7 +29,7 @@ func TestReaderJSON_Stream(t *testing.T) {
 		rd := cases.NewReaderJSON(dc, ch)
 
 		// act
-		go rd.Stream()
+		go rd.Stream(context.Background())
 		c1 := <-ch
 		c2 := <-ch
 		_, ok := <-ch
@@ -64,8 +66,8 @@ func TestReaderJSON_Stream(t *testing.T) {
 					Body: map[string]any{
 						"key": 1.0,
 					},
-					Header: http.Header{
-						"Content-Type": []string{"application/json"},
+					Header: map[string]any{
+						"Content-Type": []any{"application/json"},
 					},
 				},
 			},
@@ -93,7 +95,7 @@ func TestReaderJSON_Stream(t *testing.T) {
 		rd := cases.NewReaderJSON(dc, ch)
 
 		// act
-		go rd.Stream()
+		go rd.Stream(context.Background())
 		_, ok := <-ch
 
 		// assert
@@ -107,7 +109,7 @@ func TestReaderJSON_Stream(t *testing.T) {
 		rd := cases.NewReaderJSON(dc, ch)
 
 		// act
-		go rd.Stream()
+		go rd.Stream(context.Background())
 		c1 := <-ch
 		_, ok := <-ch
 
@@ -129,7 +131,7 @@ func TestReaderJSON_Stream(t *testing.T) {
 		rd := cases.NewReaderJSON(dc, ch)
 
 		// act
-		go rd.Stream()
+		go rd.Stream(context.Background())
 		c1 := <-ch
 		_, ok := <-ch
 
@@ -139,6 +141,80 @@ func TestReaderJSON_Stream(t *testing.T) {
 		require.EqualError(t, c1.Err, fmt.Sprintf("%s - %s", cases.ErrMalformedJSON.Error(), "invalid character 'i' looking for beginning of value"))
 		require.False(t, ok)
 	})
+
+	t.Run("case 5 - timeout of the cases", func(t *testing.T) {
+		// arrange
+		dc := json.NewDecoder(strings.NewReader(
+			`[
+				{"case_name":"case 1","timeout":"2s"},
+				{"case_name":"case 2","timeout":2}
+			]`,
+		))
+		ch := make(chan cases.CaseErr)
+		rd := cases.NewReaderJSON(dc, ch)
+
+		// act
+		go rd.Stream(context.Background())
+		c1 := <-ch
+		c2 := <-ch
+		_, ok := <-ch
+
+		// assert
+		require.NoError(t, c1.Err)
+		require.Equal(t, cases.Duration(2*time.Second), c1.Case.Timeout)
+		require.ErrorIs(t, c2.Err, cases.ErrMalformedJSON)
+		require.EqualError(t, c2.Err, "malformed json - invalid duration - 2")
+		require.False(t, ok)
+	})
+
+	t.Run("case 6 - stream stopped once the context is done", func(t *testing.T) {
+		// arrange
+		dc := json.NewDecoder(strings.NewReader(
+			`[{"case_name":"case 1"},{"case_name":"case 2"},{"case_name":"case 3"}]`,
+		))
+		ch := make(chan cases.CaseErr)
+		rd := cases.NewReaderJSON(dc, ch)
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan struct{})
+
+		// act
+		go func() {
+			defer close(done)
+			rd.Stream(ctx)
+		}()
+		c1, err1 := rd.Read()
+		cancel()
+		<-done
+		_, err2 := rd.Read()
+
+		// assert
+		require.NoError(t, err1)
+		require.Equal(t, "case 1", c1.Name)
+		require.ErrorIs(t, err2, cases.ErrEndOfLine)
+	})
+
+	t.Run("case 7 - cancellation of the context not sent as an error", func(t *testing.T) {
+		// arrange
+		dc := json.NewDecoder(strings.NewReader(
+			`[{"case_name":"case 1"},{"case_name":"case 2"},{"case_name":"case 3"}]`,
+		))
+		ch := make(chan cases.CaseErr, 3)
+		rd := cases.NewReaderJSON(dc, ch)
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		// act
+		rd.Stream(ctx)
+		var errs []error
+		for ce := range ch {
+			if ce.Err != nil {
+				errs = append(errs, ce.Err)
+			}
+		}
+
+		// assert
+		require.Empty(t, errs)
+	})
 }
 
 func TestReaderJSON_Read(t *testing.T) {
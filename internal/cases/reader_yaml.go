@@ -0,0 +1,132 @@
+package cases
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	// ErrMalformedYAML is the error returned when the YAML is malformed.
+	ErrMalformedYAML = errors.New("malformed yaml")
+)
+
+// NewReaderYAML creates a new reader of test cases in YAML format.
+// - each document of the stream is either a test case or a list of test cases
+func NewReaderYAML(decoder *yaml.Decoder, ch chan CaseErr) *ReaderYAML {
+	return &ReaderYAML{
+		decoder: decoder,
+		ch:      ch,
+	}
+}
+
+// ReaderYAML is a reader of test cases in YAML format.
+type ReaderYAML struct {
+	// decoder is the YAML decoder to use.
+	decoder *yaml.Decoder
+	// ch is the channel of test cases.
+	ch chan CaseErr
+}
+
+// Read reads the next test case.
+func (r *ReaderYAML) Read() (c Case, err error) {
+	c, err = receive(r.ch)
+	return
+}
+
+// Stream is a concurrent reader of test cases
+// - it stops once ctx is done, so it does not block on cases that are never read
+// - the cancellation of ctx is not sent as an error, the interruption is reported by the consumer
+func (r *ReaderYAML) Stream(ctx context.Context) {
+	// close the channel at the end
+	defer close(r.ch)
+
+	err := r.stream(ctx, "")
+	if err != nil && ctx.Err() == nil {
+		_ = send(ctx, r.ch, CaseErr{Err: err})
+	}
+}
+
+// stream sends the test cases of the decoder to the channel, tagged with their source file.
+func (r *ReaderYAML) stream(ctx context.Context, file string) (err error) {
+	// read the documents
+	for {
+		var doc any
+		err = r.decoder.Decode(&doc)
+		if err != nil {
+			if err == io.EOF {
+				err = nil
+				return
+			}
+			err = fmt.Errorf("%w - %s", ErrMalformedYAML, err.Error())
+			return
+		}
+
+		// read the test cases of the document
+		docs, ok := doc.([]any)
+		if !ok {
+			docs = []any{doc}
+		}
+		for _, d := range docs {
+			// - empty documents (e.g. a trailing `---`) are not test cases
+			if d == nil {
+				continue
+			}
+			var c Case
+			c, err = r.decode(d)
+			if err != nil {
+				err = fmt.Errorf("%w - %s", ErrMalformedYAML, err.Error())
+				return
+			}
+			c.File = file
+
+			err = send(ctx, r.ch, CaseErr{Case: c})
+			if err != nil {
+				return
+			}
+		}
+	}
+}
+
+// decode decodes a YAML document into a test case.
+// - the document is converted to JSON so both formats share the same schema and value types
+func (r *ReaderYAML) decode(doc any) (c Case, err error) {
+	b, err := json.Marshal(stringKeys(doc))
+	if err != nil {
+		return
+	}
+
+	err = json.Unmarshal(b, &c)
+	return
+}
+
+// stringKeys converts the keys of the YAML mappings of a value to strings, as JSON objects require.
+// - mappings with a non-string key (e.g. `1: x`) are decoded as map[any]any
+func stringKeys(v any) any {
+	switch val := v.(type) {
+	case map[any]any:
+		obj := make(map[string]any, len(val))
+		for k, e := range val {
+			obj[fmt.Sprint(k)] = stringKeys(e)
+		}
+		return obj
+	case map[string]any:
+		obj := make(map[string]any, len(val))
+		for k, e := range val {
+			obj[k] = stringKeys(e)
+		}
+		return obj
+	case []any:
+		arr := make([]any, len(val))
+		for i, e := range val {
+			arr[i] = stringKeys(e)
+		}
+		return arr
+	default:
+		return v
+	}
+}
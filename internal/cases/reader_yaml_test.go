@@ -0,0 +1,191 @@
+package cases_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/LNMMusic/tester/internal/cases"
+	"gopkg.in/yaml.v3"
+
+	"github.com/stretchr/testify/require"
+)
+
+// Tests for NewReaderYAML Stream
+func TestReaderYAML_Stream(t *testing.T) {
+	t.Run("case 1 - success to read some cases", func(t *testing.T) {
+		// arrange
+		dc := yaml.NewDecoder(strings.NewReader(`
+# first case
+case_name: case 1
+database:
+  set_up:
+    - |
+      INSERT INTO tasks (title)
+      VALUES ('task 1')
+  tear_down:
+    - DELETE FROM tasks
+request:
+  method: GET
+  path: /
+  query:
+    key: value
+  body:
+    key: 1
+  header:
+    Content-Type: [application/json]
+response:
+  code: 200
+  body:
+    key: 1
+---
+# second case
+case_name: case 2
+request:
+  method: POST
+response:
+  code: 200
+`,
+		))
+		ch := make(chan cases.CaseErr)
+		rd := cases.NewReaderYAML(dc, ch)
+
+		// act
+		go rd.Stream(context.Background())
+		c1 := <-ch
+		c2 := <-ch
+		_, ok := <-ch
+
+		// assert
+		require.NoError(t, c1.Err)
+		require.Equal(t, cases.Case{
+			Name: "case 1",
+			Database: cases.Database{
+				SetUp: []string{
+					"INSERT INTO tasks (title)\nVALUES ('task 1')\n",
+				},
+				TearDown: []string{
+					"DELETE FROM tasks",
+				},
+			},
+			Request: cases.Request{
+				Method: "GET",
+				Path:   "/",
+				Query: map[string]string{
+					"key": "value",
+				},
+				Body: map[string]any{
+					"key": 1.0,
+				},
+				Header: map[string][]string{
+					"Content-Type": {"application/json"},
+				},
+			},
+			Response: cases.Response{
+				Code: 200,
+				Body: map[string]any{
+					"key": 1.0,
+				},
+			},
+		}, c1.Case)
+		require.NoError(t, c2.Err)
+		require.Equal(t, cases.Case{
+			Name: "case 2",
+			Request: cases.Request{
+				Method: "POST",
+			},
+			Response: cases.Response{
+				Code: 200,
+			},
+		}, c2.Case)
+		require.False(t, ok)
+	})
+
+	t.Run("case 2 - success to read a list of cases in one document", func(t *testing.T) {
+		// arrange
+		dc := yaml.NewDecoder(strings.NewReader(`
+- case_name: case 1
+- case_name: case 2
+`,
+		))
+		ch := make(chan cases.CaseErr)
+		rd := cases.NewReaderYAML(dc, ch)
+
+		// act
+		go rd.Stream(context.Background())
+		c1 := <-ch
+		c2 := <-ch
+		_, ok := <-ch
+
+		// assert
+		require.Equal(t, cases.CaseErr{Case: cases.Case{Name: "case 1"}}, c1)
+		require.Equal(t, cases.CaseErr{Case: cases.Case{Name: "case 2"}}, c2)
+		require.False(t, ok)
+	})
+
+	t.Run("case 3 - success to read empty cases", func(t *testing.T) {
+		// arrange
+		dc := yaml.NewDecoder(strings.NewReader(""))
+		ch := make(chan cases.CaseErr)
+		rd := cases.NewReaderYAML(dc, ch)
+
+		// act
+		go rd.Stream(context.Background())
+		_, ok := <-ch
+
+		// assert
+		require.False(t, ok)
+	})
+
+	t.Run("case 4 - malformed yaml", func(t *testing.T) {
+		// arrange
+		dc := yaml.NewDecoder(strings.NewReader("case_name: [case 1"))
+		ch := make(chan cases.CaseErr)
+		rd := cases.NewReaderYAML(dc, ch)
+
+		// act
+		go rd.Stream(context.Background())
+		c1 := <-ch
+		_, ok := <-ch
+
+		// assert
+		require.Equal(t, cases.Case{}, c1.Case)
+		require.ErrorIs(t, c1.Err, cases.ErrMalformedYAML)
+		require.False(t, ok)
+	})
+
+	t.Run("case 5 - case with the wrong schema", func(t *testing.T) {
+		// arrange
+		dc := yaml.NewDecoder(strings.NewReader("case_name: [case 1]"))
+		ch := make(chan cases.CaseErr)
+		rd := cases.NewReaderYAML(dc, ch)
+
+		// act
+		go rd.Stream(context.Background())
+		c1 := <-ch
+		_, ok := <-ch
+
+		// assert
+		require.Equal(t, cases.Case{}, c1.Case)
+		require.ErrorIs(t, c1.Err, cases.ErrMalformedYAML)
+		require.False(t, ok)
+	})
+
+	t.Run("case 6 - non-string keys and empty documents", func(t *testing.T) {
+		// arrange
+		dc := yaml.NewDecoder(strings.NewReader("---\ncase_name: case 1\nresponse:\n  code: 200\n  body:\n    1: one\n    true: yes\n---\n---\n"))
+		ch := make(chan cases.CaseErr)
+		rd := cases.NewReaderYAML(dc, ch)
+
+		// act
+		go rd.Stream(context.Background())
+		c1 := <-ch
+		_, ok := <-ch
+
+		// assert
+		require.NoError(t, c1.Err)
+		require.Equal(t, "case 1", c1.Case.Name)
+		require.Equal(t, map[string]any{"1": "one", "true": "yes"}, c1.Case.Response.Body)
+		require.False(t, ok)
+	})
+}
@@ -4,6 +4,14 @@ import "net/http"
 
 // Reporter is a reporter of test cases.
 type Reporter interface {
-	// Report reports the result of the test case.
-	Report(c *Case, w *http.Response) (err error)
-}
\ No newline at end of file
+	// Report asserts the response of the test case and returns its result.
+	Report(c *Case, w *http.Response) (r Result, err error)
+}
+
+// ResultReporter is a reporter of the results of test cases.
+type ResultReporter interface {
+	// ReportResult reports the result of a test case once it has been processed.
+	ReportResult(r Result) (err error)
+	// ReportRun reports the aggregated result of the whole run.
+	ReportRun(rr RunResult) (err error)
+}
@@ -1,14 +1,18 @@
 package cases
 
 import (
-	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
-	"reflect"
+	"os"
+	"sort"
+	"strings"
 )
 
 // NewReporterDefault creates a new default reporter.
-func NewReporterDefault(excludedHeaders []string) *ReporterDefault {
+// - match is the default mode used to match the bodies (exact by default)
+// - out is the writer where the results are printed (stdout by default)
+func NewReporterDefault(excludedHeaders []string, match MatchMode, out io.Writer) *ReporterDefault {
 	// default excluded headers
 	defaultExcludedHeaders := []string{"Date", "Content-Length"}
 	if excludedHeaders != nil {
@@ -16,9 +20,26 @@ func NewReporterDefault(excludedHeaders []string) *ReporterDefault {
 			defaultExcludedHeaders = excludedHeaders
 		}
 	}
+	// default match mode
+	defaultMatch := MatchExact
+	if match != "" {
+		defaultMatch = match
+	}
+	// default output
+	var defaultOut io.Writer = os.Stdout
+	if out != nil {
+		defaultOut = out
+	}
+	color := false
+	if f, ok := defaultOut.(*os.File); ok {
+		color = isTerminal(f)
+	}
 
 	return &ReporterDefault{
 		excludedHeaders: defaultExcludedHeaders,
+		match:           defaultMatch,
+		out:             defaultOut,
+		color:           color,
 	}
 }
 
@@ -26,53 +47,152 @@ func NewReporterDefault(excludedHeaders []string) *ReporterDefault {
 type ReporterDefault struct {
 	// excluded headers
 	excludedHeaders []string
+	// match is the default mode used to match the bodies.
+	match MatchMode
+	// out is the writer where the results are printed.
+	out io.Writer
+	// color is true if the output is colored.
+	color bool
 }
 
-func (r *ReporterDefault) Report(c *Case, w *http.Response) (err error) {
+// Report asserts the response of the test case and returns its result.
+func (r *ReporterDefault) Report(c *Case, w *http.Response) (rs Result, err error) {
 	// expectations
 	expectedCode := c.Response.Code
-	expectedBody := c.Response.Body
-	expectedHeader := c.Response.Header
+	expectedHeader := make(map[string]any, len(c.Response.Header))
+	for k, v := range c.Response.Header {
+		expectedHeader[k] = v
+	}
 	// actual
 	actualCode := w.StatusCode
-	actualBody := any(nil)
-	err = json.NewDecoder(w.Body).Decode(&actualBody)
+	raw, err := io.ReadAll(w.Body)
 	if err != nil {
-		return err
+		return
 	}
 	actualHeader := w.Header
 
+	// body
+	match := c.Response.Match
+	if match == "" {
+		match = r.match
+	}
+	bodyType := responseBodyType(c.Response.BodyType, actualHeader.Get("Content-Type"), raw)
+	actualBody, bodyVerdict, err := diffBody(c, bodyType, raw, match)
+	if err != nil {
+		return
+	}
+
+	// record
+	record := &ReceivedResponse{
+		Code:   actualCode,
+		Header: actualHeader.Clone(),
+		Body:   actualBody,
+	}
+	expected := c.Response
+
 	// exclusions
+	actualHeader = actualHeader.Clone()
 	for _, h := range r.excludedHeaders {
-		delete(expectedHeader, h)
-		delete(actualHeader, h)
+		actualHeader.Del(h)
+		for k := range expectedHeader {
+			if strings.EqualFold(k, h) {
+				delete(expectedHeader, k)
+			}
+		}
 	}
 
 	// verify
-	validCode := expectedCode == actualCode
-	validBody := reflect.DeepEqual(expectedBody, actualBody)
-	validHeader := reflect.DeepEqual(expectedHeader, actualHeader)
-	if !(validCode && validBody && validHeader) {
-		fmt.Printf("> Case '%s': FAIL\n", c.Name)
+	headerDiff, err := DiffHeader(expectedHeader, actualHeader, c.Response.HeaderMatch)
+	if err != nil {
+		return
+	}
+	rs = NewResult(c.Name,
+		Verdict{Field: "code", Valid: expectedCode == actualCode, Expected: expectedCode, Actual: actualCode},
+		bodyVerdict,
+		Verdict{Field: "header", Valid: len(headerDiff) == 0, Expected: expectedHeader, Actual: actualHeader, Diff: headerDiff},
+	)
+	rs.Request = sentRequest(c, w)
+	rs.Response = record
+	rs.Expected = &expected
+	return
+}
+
+// sentRequest returns the request that was sent for the test case.
+// - the request of the response is used when available, as it holds the final url and headers
+func sentRequest(c *Case, w *http.Response) (rq *SentRequest) {
+	rq = &SentRequest{
+		Method: c.Request.Method,
+		URL:    c.Request.Path,
+		Header: c.Request.Header,
+		Body:   c.Request.Body,
+	}
+	if w.Request != nil {
+		rq.Method = w.Request.Method
+		rq.URL = w.Request.URL.String()
+		rq.Host = w.Request.Host
+		rq.Header = w.Request.Header.Clone()
+	}
+	return
+}
 
-		if !validCode {
-			fmt.Printf("- expected code: %d\n", expectedCode)
-			fmt.Printf("- actual code: %d\n", actualCode)
+// ReportResult prints the result of a test case.
+func (r *ReporterDefault) ReportResult(rs Result) (err error) {
+	switch rs.Status {
+	case StatusPassed:
+		fmt.Fprintf(r.out, "> Case '%s': PASS\n", rs.Name)
+		fmt.Fprint(r.out, rs.steps(r.color))
+		names := make([]string, 0, len(rs.Captured))
+		for name := range rs.Captured {
+			names = append(names, name)
 		}
-		if !validBody {
-			fmt.Printf("- expected body: %v\n", expectedBody)
-			fmt.Printf("- actual body: %v\n", actualBody)
+		sort.Strings(names)
+		for _, name := range names {
+			fmt.Fprintf(r.out, "- captured %s: %s\n", name, compactJSON(rs.Captured[name]))
 		}
-		if !validHeader {
-			fmt.Printf("- expected header: %v\n", expectedHeader)
-			fmt.Printf("- actual header: %v\n", actualHeader)
+	case StatusSkipped, StatusCanceled:
+		fmt.Fprintf(r.out, "> Case '%s': %s\n", rs.Name, rs.Status.label())
+	case StatusFailed:
+		fmt.Fprintf(r.out, "> Case '%s': FAIL\n", rs.Name)
+		if rs.File != "" {
+			fmt.Fprintf(r.out, "- file: %s\n", rs.File)
 		}
-		fmt.Println()
-		return
+		fmt.Fprint(r.out, rs.failure(r.color))
+	case StatusErrored, StatusTimeout:
+		fmt.Fprintf(r.out, "> Case '%s': %s\n", rs.Name, rs.Status.label())
+		if rs.File != "" {
+			fmt.Fprintf(r.out, "- file: %s\n", rs.File)
+		}
+		fmt.Fprint(r.out, rs.steps(r.color))
+		// - the error of a query already holds its index, text and cause
+		fmt.Fprintf(r.out, "- error: %v\n", rs.Err)
 	}
+	fmt.Fprintln(r.out)
 
-	fmt.Printf("> Case '%s': PASS\n", c.Name)
-	fmt.Println()
+	return
+}
 
-	return nil
-}
\ No newline at end of file
+// ReportRun prints the summary of the run.
+func (r *ReporterDefault) ReportRun(rr RunResult) (err error) {
+	fmt.Fprintf(r.out, "> Summary: %d cases in %s\n", rr.Total(), rr.Duration)
+	fmt.Fprintf(r.out, "- passed: %d\n", rr.Passed)
+	fmt.Fprintf(r.out, "- failed: %d\n", rr.Failed)
+	fmt.Fprintf(r.out, "- errored: %d\n", rr.Errored)
+	fmt.Fprintf(r.out, "- skipped: %d\n", rr.Skipped)
+	fmt.Fprintf(r.out, "- timed out: %d\n", rr.TimedOut)
+	fmt.Fprintf(r.out, "- canceled: %d\n", rr.Canceled)
+	fmt.Fprintln(r.out)
+
+	return
+}
+
+// isTerminal reports whether a file is a terminal, honoring the NO_COLOR convention.
+func isTerminal(f *os.File) bool {
+	if os.Getenv("NO_COLOR") != "" {
+		return false
+	}
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
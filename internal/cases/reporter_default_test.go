@@ -1,10 +1,14 @@
 package cases_test
 
 import (
+	"bytes"
+	"errors"
+	"fmt"
 	"io"
 	"net/http"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/LNMMusic/tester/internal/cases"
 
@@ -15,7 +19,7 @@ import (
 func TestReporterDefault_Report(t *testing.T) {
 	t.Run("case 1 - success report", func(t *testing.T) {
 		// arrange
-		rp := cases.NewReporterDefault(nil)
+		rp := cases.NewReporterDefault(nil, "", nil)
 
 		// act
 		w := &http.Response{
@@ -41,20 +45,23 @@ func TestReporterDefault_Report(t *testing.T) {
 						"bool": true,
 					},
 				},
-				Header: http.Header{
-					"Content-Type": {"application/json"},
+				Header: map[string]any{
+					"Content-Type": []any{"application/json"},
 				},
 			},
 		}
-		err := rp.Report(c, w)
+		r, err := rp.Report(c, w)
 
 		// assert
 		require.NoError(t, err)
+		require.Equal(t, cases.StatusPassed, r.Status)
+		require.Equal(t, 200, r.Response.Code)
+		require.Equal(t, c.Response.Code, r.Expected.Code)
 	})
 
 	t.Run("case 2 - success report - excluded headers", func(t *testing.T) {
 		// arrange
-		rp := cases.NewReporterDefault([]string{"Date", "Content-Length"})
+		rp := cases.NewReporterDefault([]string{"Date", "Content-Length"}, "", nil)
 
 		// act
 		w := &http.Response{
@@ -82,20 +89,21 @@ func TestReporterDefault_Report(t *testing.T) {
 						"bool": true,
 					},
 				},
-				Header: http.Header{
-					"Content-Type": {"application/json"},
+				Header: map[string]any{
+					"Content-Type": []any{"application/json"},
 				},
 			},
 		}
-		err := rp.Report(c, w)
+		r, err := rp.Report(c, w)
 
 		// assert
 		require.NoError(t, err)
+		require.Equal(t, cases.StatusPassed, r.Status)
 	})
 
 	t.Run("case 3 - failed report - code", func(t *testing.T) {
 		// arrange
-		rp := cases.NewReporterDefault(nil)
+		rp := cases.NewReporterDefault(nil, "", nil)
 
 		// act
 		w := &http.Response{
@@ -121,20 +129,21 @@ func TestReporterDefault_Report(t *testing.T) {
 						"bool": true,
 					},
 				},
-				Header: http.Header{
-					"Content-Type": {"application/json"},
+				Header: map[string]any{
+					"Content-Type": []any{"application/json"},
 				},
 			},
 		}
-		err := rp.Report(c, w)
+		r, err := rp.Report(c, w)
 
 		// assert
 		require.NoError(t, err)
+		require.Equal(t, cases.StatusFailed, r.Status)
 	})
 
 	t.Run("case 4 - failed report - body", func(t *testing.T) {
 		// arrange
-		rp := cases.NewReporterDefault(nil)
+		rp := cases.NewReporterDefault(nil, "", nil)
 
 		// act
 		w := &http.Response{
@@ -160,20 +169,24 @@ func TestReporterDefault_Report(t *testing.T) {
 						"bool": false,
 					},
 				},
-				Header: http.Header{
-					"Content-Type": {"application/json"},
+				Header: map[string]any{
+					"Content-Type": []any{"application/json"},
 				},
 			},
 		}
-		err := rp.Report(c, w)
+		r, err := rp.Report(c, w)
 
 		// assert
 		require.NoError(t, err)
+		require.Equal(t, cases.StatusFailed, r.Status)
+		require.Equal(t, []cases.Difference{
+			{Path: "/data/bool", Kind: cases.DiffChanged, Expected: false, Actual: true},
+		}, r.Verdicts[1].Diff)
 	})
 
 	t.Run("case 5 - failed report - header", func(t *testing.T) {
 		// arrange
-		rp := cases.NewReporterDefault(nil)
+		rp := cases.NewReporterDefault(nil, "", nil)
 
 		// act
 		w := &http.Response{
@@ -199,18 +212,80 @@ func TestReporterDefault_Report(t *testing.T) {
 						"bool": true,
 					},
 				},
-				Header: http.Header{},
+				Header: map[string]any{
+					"Content-Type": "text/plain",
+				},
 			},
 		}
-		err := rp.Report(c, w)
+		r, err := rp.Report(c, w)
 
 		// assert
 		require.NoError(t, err)
+		require.Equal(t, cases.StatusFailed, r.Status)
 	})
 
-	t.Run("case 6 - error decode body", func(t *testing.T) {
+	t.Run("case 6 - success report - subset match", func(t *testing.T) {
 		// arrange
-		rp := cases.NewReporterDefault(nil)
+		rp := cases.NewReporterDefault(nil, cases.MatchExact, nil)
+
+		// act
+		w := &http.Response{
+			StatusCode: 200,
+			Body: io.NopCloser(strings.NewReader(
+				`{"message":"success","data":{"id":1,"created_at":"2023-01-01"}}`,
+			)),
+			Header: http.Header{
+				"Content-Type": {"application/json"},
+			},
+		}
+		c := &cases.Case{
+			Name: "case 6",
+			Response: cases.Response{
+				Code: 200,
+				Body: map[string]any{
+					"data": map[string]any{
+						"id": 1.0,
+					},
+				},
+				Header: map[string]any{
+					"Content-Type": []any{"application/json"},
+				},
+				Match: cases.MatchSubset,
+			},
+		}
+		r, err := rp.Report(c, w)
+
+		// assert
+		require.NoError(t, err)
+		require.Equal(t, cases.StatusPassed, r.Status)
+	})
+
+	t.Run("case 7 - error unknown match mode", func(t *testing.T) {
+		// arrange
+		rp := cases.NewReporterDefault(nil, "partial", nil)
+
+		// act
+		w := &http.Response{
+			StatusCode: 200,
+			Body:       io.NopCloser(strings.NewReader(`{}`)),
+			Header:     http.Header{},
+		}
+		c := &cases.Case{
+			Name: "case 7",
+			Response: cases.Response{
+				Code: 200,
+				Body: map[string]any{},
+			},
+		}
+		_, err := rp.Report(c, w)
+
+		// assert
+		require.ErrorIs(t, err, cases.ErrUnknownMatchMode)
+	})
+
+	t.Run("case 8 - error decode body", func(t *testing.T) {
+		// arrange
+		rp := cases.NewReporterDefault(nil, "", nil)
 
 		// act
 		w := &http.Response{
@@ -236,15 +311,222 @@ func TestReporterDefault_Report(t *testing.T) {
 						"bool": true,
 					},
 				},
-				Header: http.Header{
-					"Content-Type": {"application/json"},
+				Header: map[string]any{
+					"Content-Type": []any{"application/json"},
 				},
 			},
 		}
-		err := rp.Report(c, w)
+		_, err := rp.Report(c, w)
 
 		// assert
 		require.Error(t, err)
 		require.EqualError(t, err, "invalid character 'm' looking for beginning of value")
 	})
-}
\ No newline at end of file
+
+	t.Run("case 9 - success report - non-json bodies", func(t *testing.T) {
+		// arrange
+		rp := cases.NewReporterDefault(nil, "", nil)
+		tcs := []struct {
+			name        string
+			contentType string
+			raw         string
+			response    cases.Response
+		}{
+			{"empty", "", "", cases.Response{Code: 200}},
+			{"empty asserted", "text/plain", "", cases.Response{Code: 200, BodyType: cases.BodyEmpty}},
+			{"text", "text/plain; charset=utf-8", "pong", cases.Response{Code: 200, Body: "pong"}},
+			{"text regex", "text/html", "<h1>Welcome, alice</h1>", cases.Response{Code: 200, Body: map[string]any{"$regex": "Welcome, \\w+"}}},
+			{"xml", "application/xml", "<task id=\"1\" done=\"false\">\n  <title>task 1</title>\n</task>", cases.Response{Code: 200, Body: "<task done=\"false\" id=\"1\"><title>task 1</title></task>"}},
+			{"binary", "application/pdf", "%PDF", cases.Response{Code: 200, Body: map[string]any{"sha256": "315d429b7714cedb6ad04ac31240145257692630457f3c88253c5beceac76027"}}},
+			{"binary base64", "application/octet-stream", "%PDF", cases.Response{Code: 200, Body: "JVBERg=="}},
+			{"binary empty", "application/octet-stream", "", cases.Response{Code: 200, BodyType: cases.BodyBinary}},
+			{"xml mixed content", "text/xml", "<p>\n  <a>x <b/> y</a>\n</p>", cases.Response{Code: 200, Body: "<p><a>x <b/> y</a></p>"}},
+		}
+		for _, tc := range tcs {
+			// act
+			w := &http.Response{
+				StatusCode: 200,
+				Body:       io.NopCloser(strings.NewReader(tc.raw)),
+				Header:     http.Header{"Content-Type": {tc.contentType}},
+			}
+			r, err := rp.Report(&cases.Case{Name: tc.name, Response: tc.response}, w)
+
+			// assert
+			require.NoError(t, err, tc.name)
+			require.Equal(t, cases.StatusPassed, r.Status, tc.name+": "+r.Failure())
+		}
+	})
+
+	t.Run("case 10 - failed report - non-json bodies", func(t *testing.T) {
+		// arrange
+		rp := cases.NewReporterDefault(nil, "", nil)
+		tcs := []struct {
+			name        string
+			contentType string
+			raw         string
+			response    cases.Response
+			failure     string
+		}{
+			{"not empty", "text/plain", "oops", cases.Response{Code: 200, BodyType: cases.BodyEmpty},
+				"- body diff (- expected, + actual):\n  - /: \"\"\n  + /: \"oops\"\n"},
+			{"text", "text/plain", "pong", cases.Response{Code: 200, Body: "ping"},
+				"- body diff (- expected, + actual):\n  - /: \"ping\"\n  + /: \"pong\"\n"},
+			{"xml", "text/xml", "<task><title>task 2</title></task>", cases.Response{Code: 200, Body: "<task><title>task 1</title></task>"},
+				"- body diff (- expected, + actual):\n  - /children/0/text: \"task 1\"\n  + /children/0/text: \"task 2\"\n"},
+			{"binary", "image/png", "png", cases.Response{Code: 200, Body: map[string]any{"sha256": "00"}},
+				"- body diff (- expected, + actual):\n  - /sha256: \"00\"\n  + /sha256: \"8f8cbb7dcf46e0bc7d53265749a6c17d116093a6ba95e442764060c76fd4a86c\"\n"},
+			{"binary not expected", "image/png", "png", cases.Response{Code: 200},
+				"- body diff (- expected, + actual):\n  ! /: expected null null, actual object {\"sha256\":\"8f8cbb7dcf46e0bc7d53265749a6c17d116093a6ba95e442764060c76fd4a86c\",\"size\":3}\n"},
+			{"xml mixed content whitespace", "text/xml", "<a>x  <b/>y</a>", cases.Response{Code: 200, Body: "<a>x <b/> y</a>"},
+				"- body diff (- expected, + actual):\n  - /children/0: \"x \"\n  + /children/0: \"x  \"\n  - /children/2: \" y\"\n  + /children/2: \"y\"\n"},
+		}
+		for _, tc := range tcs {
+			// act
+			w := &http.Response{
+				StatusCode: 200,
+				Body:       io.NopCloser(strings.NewReader(tc.raw)),
+				Header:     http.Header{"Content-Type": {tc.contentType}},
+			}
+			r, err := rp.Report(&cases.Case{Name: tc.name, Response: tc.response}, w)
+
+			// assert
+			require.NoError(t, err, tc.name)
+			require.Equal(t, cases.StatusFailed, r.Status, tc.name)
+			require.Equal(t, tc.failure, r.Failure(), tc.name)
+		}
+	})
+
+	t.Run("case 11 - success report - sent request with host override", func(t *testing.T) {
+		// arrange
+		rp := cases.NewReporterDefault(nil, "", nil)
+		rq, err := http.NewRequest(http.MethodGet, "http://localhost:8080/ping?a=1", nil)
+		require.NoError(t, err)
+		rq.Host = "api.example.com"
+		rq.Header.Set("Accept", "text/plain")
+
+		// act
+		w := &http.Response{
+			StatusCode: 200,
+			Body:       io.NopCloser(strings.NewReader("")),
+			Header:     http.Header{},
+			Request:    rq,
+		}
+		c := &cases.Case{
+			Name:     "case 11",
+			Request:  cases.Request{Method: http.MethodGet, Path: "/ping?a=1", Header: http.Header{"Host": {"api.example.com"}}},
+			Response: cases.Response{Code: 200, BodyType: cases.BodyEmpty},
+		}
+		r, err := rp.Report(c, w)
+
+		// assert
+		require.NoError(t, err)
+		require.Equal(t, cases.StatusPassed, r.Status, r.Failure())
+		require.Equal(t, &cases.SentRequest{
+			Method: http.MethodGet,
+			URL:    "http://localhost:8080/ping?a=1",
+			Host:   "api.example.com",
+			Header: http.Header{"Accept": {"text/plain"}},
+		}, r.Request)
+	})
+}
+
+// Tests for ReporterDefault ReportResult
+func TestReporterDefault_ReportResult(t *testing.T) {
+	t.Run("case 1 - passed result with captured variables", func(t *testing.T) {
+		// arrange
+		var out bytes.Buffer
+		rp := cases.NewReporterDefault(nil, "", &out)
+
+		// act
+		err := rp.ReportResult(cases.Result{
+			Name:     "case 1",
+			Status:   cases.StatusPassed,
+			Captured: map[string]any{"token": "abc", "id": 1.0},
+		})
+
+		// assert
+		require.NoError(t, err)
+		require.Equal(t, "> Case 'case 1': PASS\n- captured id: 1\n- captured token: \"abc\"\n\n", out.String())
+	})
+
+	t.Run("case 2 - failed result", func(t *testing.T) {
+		// arrange
+		var out bytes.Buffer
+		rp := cases.NewReporterDefault(nil, "", &out)
+
+		// act
+		err := rp.ReportResult(cases.Result{
+			Name:   "case 2",
+			File:   "tasks.json",
+			Status: cases.StatusFailed,
+			Verdicts: []cases.Verdict{
+				{Field: "code", Valid: true, Expected: 200, Actual: 200},
+				{Field: "code", Valid: false, Expected: 201, Actual: 404},
+			},
+		})
+
+		// assert
+		require.NoError(t, err)
+		require.Equal(t, "> Case 'case 2': FAIL\n- file: tasks.json\n- expected code: 201\n- actual code: 404\n\n", out.String())
+	})
+
+	t.Run("case 3 - errored result with a query error printed once", func(t *testing.T) {
+		// arrange
+		var out bytes.Buffer
+		rp := cases.NewReporterDefault(nil, "", &out)
+
+		// act
+		err := rp.ReportResult(cases.Result{
+			Name:   "case 3",
+			Status: cases.StatusErrored,
+			Err: fmt.Errorf("tester: database error. %w", &cases.QueryError{
+				Index: 1,
+				Query: "INSERT INTO tasks",
+				Case:  "case 3",
+				Err:   errors.New("duplicate key"),
+			}),
+		})
+
+		// assert
+		require.NoError(t, err)
+		require.Equal(t, "> Case 'case 3': ERROR\n- error: tester: database error. error executing query 1 of case 'case 3': INSERT INTO tasks - duplicate key\n\n", out.String())
+	})
+
+	t.Run("case 4 - canceled result", func(t *testing.T) {
+		// arrange
+		var out bytes.Buffer
+		rp := cases.NewReporterDefault(nil, "", &out)
+
+		// act
+		err := rp.ReportResult(cases.Result{Name: "case 4", Status: cases.StatusCanceled})
+
+		// assert
+		require.NoError(t, err)
+		require.Equal(t, "> Case 'case 4': CANCELED\n\n", out.String())
+	})
+}
+
+// Tests for ReporterDefault ReportRun
+func TestReporterDefault_ReportRun(t *testing.T) {
+	t.Run("case 1 - summary of the run", func(t *testing.T) {
+		// arrange
+		var out bytes.Buffer
+		rp := cases.NewReporterDefault(nil, "", &out)
+		rr := cases.RunResult{}
+		rr.Add(cases.Result{Status: cases.StatusPassed})
+		rr.Add(cases.Result{Status: cases.StatusPassed})
+		rr.Add(cases.Result{Status: cases.StatusFailed})
+		rr.Add(cases.Result{Status: cases.StatusErrored})
+		rr.Add(cases.Result{Status: cases.StatusSkipped})
+		rr.Add(cases.Result{Status: cases.StatusTimeout})
+		rr.Add(cases.Result{Status: cases.StatusCanceled})
+		rr.Duration = time.Second
+
+		// act
+		err := rp.ReportRun(rr)
+
+		// assert
+		require.NoError(t, err)
+		require.Equal(t, "> Summary: 7 cases in 1s\n- passed: 2\n- failed: 1\n- errored: 1\n- skipped: 1\n- timed out: 1\n- canceled: 1\n\n", out.String())
+	})
+}
@@ -0,0 +1,130 @@
+package cases
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+)
+
+// NewReporterJSON creates a new reporter of results in JSON lines format.
+func NewReporterJSON(path string) *ReporterJSON {
+	return &ReporterJSON{
+		path: path,
+	}
+}
+
+// ReporterJSON is a reporter that writes the result of each test case as a line of JSON.
+// - results are written as soon as each test case is processed, in order of completion
+type ReporterJSON struct {
+	// path is the path of the JSON lines file.
+	path string
+	// f is the file being written, opened on the first result.
+	f *os.File
+	// enc is the encoder of the file.
+	enc *json.Encoder
+}
+
+// jsonResult is a line of the JSON lines report.
+type jsonResult struct {
+	Name              string            `json:"name"`
+	File              string            `json:"file,omitempty"`
+	Status            Status            `json:"status"`
+	Error             string            `json:"error,omitempty"`
+	Request           *SentRequest      `json:"request,omitempty"`
+	Response          *ReceivedResponse `json:"response,omitempty"`
+	Expected          *Response         `json:"expected,omitempty"`
+	Verdicts          []Verdict         `json:"verdicts"`
+	Captured          map[string]any    `json:"captured,omitempty"`
+	Steps             []jsonResult      `json:"steps,omitempty"`
+	StartedAt         time.Time         `json:"started_at"`
+	DurationMs        float64           `json:"duration_ms"`
+	RequestDurationMs float64           `json:"request_duration_ms,omitempty"`
+}
+
+// ReportResult writes the result of a test case as a line of JSON.
+func (r *ReporterJSON) ReportResult(rs Result) (err error) {
+	// open the file on the first result
+	if r.f == nil {
+		err = r.open()
+		if err != nil {
+			return
+		}
+	}
+
+	// line
+	line := newJSONResult(rs)
+
+	// write
+	err = r.enc.Encode(line)
+	if err != nil {
+		err = fmt.Errorf("%w - %v", ErrWriteReport, err)
+		return
+	}
+
+	return
+}
+
+// newJSONResult creates a line of the JSON lines report out of a result.
+func newJSONResult(rs Result) (line jsonResult) {
+	line = jsonResult{
+		Name:       rs.Name,
+		File:       rs.File,
+		Status:     rs.Status,
+		Request:    rs.Request,
+		Response:   rs.Response,
+		Expected:   rs.Expected,
+		Verdicts:   rs.Verdicts,
+		Captured:   rs.Captured,
+		StartedAt:  rs.Started,
+		DurationMs: durationMs(rs.Duration),
+	}
+	if rs.Err != nil {
+		line.Error = rs.Err.Error()
+	}
+	if rs.Response != nil {
+		line.RequestDurationMs = durationMs(rs.Response.Elapsed)
+	}
+	if line.Verdicts == nil {
+		line.Verdicts = []Verdict{}
+	}
+	for _, step := range rs.Steps {
+		line.Steps = append(line.Steps, newJSONResult(step))
+	}
+	return
+}
+
+// ReportRun closes the file, creating it if no result was reported.
+func (r *ReporterJSON) ReportRun(rr RunResult) (err error) {
+	if r.f == nil {
+		err = r.open()
+		if err != nil {
+			return
+		}
+	}
+
+	err = r.f.Close()
+	r.f, r.enc = nil, nil
+	if err != nil {
+		err = fmt.Errorf("%w - %v", ErrWriteReport, err)
+		return
+	}
+
+	return
+}
+
+// open creates the file of the report.
+func (r *ReporterJSON) open() (err error) {
+	r.f, err = os.Create(r.path)
+	if err != nil {
+		err = fmt.Errorf("%w - %v", ErrWriteReport, err)
+		return
+	}
+	r.enc = json.NewEncoder(r.f)
+	return
+}
+
+// durationMs returns a duration in milliseconds.
+func durationMs(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
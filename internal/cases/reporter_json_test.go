@@ -0,0 +1,96 @@
+package cases_test
+
+import (
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/LNMMusic/tester/internal/cases"
+
+	"github.com/stretchr/testify/require"
+)
+
+// Tests for ReporterJSON
+func TestReporterJSON_Report(t *testing.T) {
+	t.Run("case 1 - success to write a line per result", func(t *testing.T) {
+		// arrange
+		path := filepath.Join(t.TempDir(), "report.jsonl")
+		rp := cases.NewReporterJSON(path)
+		started := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+
+		// act
+		err1 := rp.ReportResult(cases.Result{
+			Name:   "case 1",
+			File:   "tasks.json",
+			Status: cases.StatusFailed,
+			Verdicts: []cases.Verdict{
+				{Field: "code", Valid: false, Expected: 200, Actual: 404},
+			},
+			Request: &cases.SentRequest{
+				Method: http.MethodGet,
+				URL:    "http://localhost:8080/tasks/1",
+				Header: http.Header{"Accept": {"application/json"}},
+			},
+			Response: &cases.ReceivedResponse{
+				Code:    404,
+				Header:  http.Header{"Content-Type": {"application/json"}},
+				Body:    map[string]any{"message": "task not found"},
+				Elapsed: 2 * time.Millisecond,
+			},
+			Expected: &cases.Response{Code: 200},
+			Started:  started,
+			Duration: 3 * time.Millisecond,
+		})
+		err2 := rp.ReportResult(cases.Result{
+			Name:    "case 2",
+			Status:  cases.StatusErrored,
+			Err:     errors.New("tester: request error"),
+			Started: started,
+		})
+		err3 := rp.ReportRun(cases.RunResult{})
+
+		// assert
+		require.NoError(t, err1)
+		require.NoError(t, err2)
+		require.NoError(t, err3)
+		b, err := os.ReadFile(path)
+		require.NoError(t, err)
+		expected := `{"name":"case 1","file":"tasks.json","status":"failed",` +
+			`"request":{"method":"GET","url":"http://localhost:8080/tasks/1","header":{"Accept":["application/json"]},"body":null},` +
+			`"response":{"code":404,"header":{"Content-Type":["application/json"]},"body":{"message":"task not found"}},` +
+			`"expected":{"code":200,"body":null,"header":null},` +
+			`"verdicts":[{"field":"code","valid":false,"expected":200,"actual":404}],` +
+			`"started_at":"2023-01-01T00:00:00Z","duration_ms":3,"request_duration_ms":2}` + "\n" +
+			`{"name":"case 2","status":"errored","error":"tester: request error","verdicts":[],"started_at":"2023-01-01T00:00:00Z","duration_ms":0}` + "\n"
+		require.Equal(t, expected, string(b))
+	})
+
+	t.Run("case 2 - success to write an empty report", func(t *testing.T) {
+		// arrange
+		path := filepath.Join(t.TempDir(), "report.jsonl")
+		rp := cases.NewReporterJSON(path)
+
+		// act
+		err := rp.ReportRun(cases.RunResult{})
+
+		// assert
+		require.NoError(t, err)
+		b, err := os.ReadFile(path)
+		require.NoError(t, err)
+		require.Empty(t, b)
+	})
+
+	t.Run("case 3 - error creating the report", func(t *testing.T) {
+		// arrange
+		rp := cases.NewReporterJSON(filepath.Join(t.TempDir(), "missing", "report.jsonl"))
+
+		// act
+		err := rp.ReportResult(cases.Result{Name: "case 1"})
+
+		// assert
+		require.ErrorIs(t, err, cases.ErrWriteReport)
+	})
+}
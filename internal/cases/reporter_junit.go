@@ -0,0 +1,158 @@
+package cases
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+)
+
+var (
+	// ErrWriteReport is the error returned when a report can not be written.
+	ErrWriteReport = errors.New("write report error")
+)
+
+// NewReporterJUnit creates a new reporter of results in JUnit XML format.
+func NewReporterJUnit(path string) *ReporterJUnit {
+	return &ReporterJUnit{
+		path: path,
+	}
+}
+
+// ReporterJUnit is a reporter that writes the results of a run to a JUnit XML file.
+// - test cases are grouped in test suites by their source file
+type ReporterJUnit struct {
+	// path is the path of the XML file.
+	path string
+}
+
+// junitTestSuites is the root element of a JUnit XML report.
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Errors   int              `xml:"errors,attr"`
+	Skipped  int              `xml:"skipped,attr"`
+	Time     string           `xml:"time,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+// junitTestSuite is a test suite of a JUnit XML report.
+type junitTestSuite struct {
+	Name     string          `xml:"name,attr"`
+	Tests    int             `xml:"tests,attr"`
+	Failures int             `xml:"failures,attr"`
+	Errors   int             `xml:"errors,attr"`
+	Skipped  int             `xml:"skipped,attr"`
+	Time     string          `xml:"time,attr"`
+	Cases    []junitTestCase `xml:"testcase"`
+}
+
+// junitTestCase is a test case of a JUnit XML report.
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	Classname string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitMessage `xml:"failure,omitempty"`
+	Error     *junitMessage `xml:"error,omitempty"`
+	Skipped   *junitMessage `xml:"skipped,omitempty"`
+}
+
+// junitMessage is the failure, error or skip message of a JUnit XML test case.
+type junitMessage struct {
+	Message string `xml:"message,attr,omitempty"`
+	Text    string `xml:",chardata"`
+}
+
+// ReportResult does nothing, as results are written at the end of the run.
+func (r *ReporterJUnit) ReportResult(rs Result) (err error) {
+	return
+}
+
+// ReportRun writes the results of the run to the XML file.
+func (r *ReporterJUnit) ReportRun(rr RunResult) (err error) {
+	// report
+	report := junitTestSuites{
+		Name:     "tester",
+		Tests:    rr.Total(),
+		Failures: rr.Failed,
+		Errors:   rr.Errored + rr.TimedOut,
+		Skipped:  rr.Skipped + rr.Canceled,
+		Time:     junitTime(rr.Duration),
+	}
+	// - suites: by file, in order of appearance
+	index := make(map[string]int)
+	durations := make([]time.Duration, 0)
+	for _, rs := range rr.Results {
+		i, ok := index[rs.File]
+		if !ok {
+			name := rs.File
+			if name == "" {
+				name = "tester"
+			}
+			i = len(report.Suites)
+			index[rs.File] = i
+			report.Suites = append(report.Suites, junitTestSuite{Name: name})
+			durations = append(durations, 0)
+		}
+		r.add(&report.Suites[i], rs)
+		durations[i] += rs.Duration
+		report.Suites[i].Time = junitTime(durations[i])
+	}
+
+	// write
+	f, err := os.Create(r.path)
+	if err != nil {
+		err = fmt.Errorf("%w - %v", ErrWriteReport, err)
+		return
+	}
+	defer f.Close()
+
+	_, err = f.WriteString(xml.Header)
+	if err != nil {
+		err = fmt.Errorf("%w - %v", ErrWriteReport, err)
+		return
+	}
+	enc := xml.NewEncoder(f)
+	enc.Indent("", "  ")
+	err = enc.Encode(report)
+	if err != nil {
+		err = fmt.Errorf("%w - %v", ErrWriteReport, err)
+		return
+	}
+
+	return
+}
+
+// add adds the result of a test case to a suite.
+func (r *ReporterJUnit) add(s *junitTestSuite, rs Result) {
+	tc := junitTestCase{
+		Name:      rs.Name,
+		Classname: s.Name,
+		Time:      junitTime(rs.Duration),
+	}
+	switch rs.Status {
+	case StatusFailed:
+		s.Failures++
+		tc.Failure = &junitMessage{Message: "assertions failed", Text: rs.Failure()}
+	case StatusErrored, StatusTimeout:
+		s.Errors++
+		tc.Error = &junitMessage{Message: fmt.Sprint(rs.Err), Text: fmt.Sprint(rs.Err)}
+	case StatusSkipped:
+		s.Skipped++
+		tc.Skipped = &junitMessage{}
+	case StatusCanceled:
+		s.Skipped++
+		tc.Skipped = &junitMessage{Message: "canceled"}
+	}
+
+	s.Tests++
+	s.Cases = append(s.Cases, tc)
+}
+
+// junitTime formats a duration in seconds, as expected by JUnit.
+func junitTime(d time.Duration) string {
+	return fmt.Sprintf("%.3f", d.Seconds())
+}
@@ -0,0 +1,71 @@
+package cases_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/LNMMusic/tester/internal/cases"
+
+	"github.com/stretchr/testify/require"
+)
+
+// Tests for ReporterJUnit ReportRun
+func TestReporterJUnit_ReportRun(t *testing.T) {
+	t.Run("case 1 - success to write the report", func(t *testing.T) {
+		// arrange
+		path := filepath.Join(t.TempDir(), "report.xml")
+		rp := cases.NewReporterJUnit(path)
+		rr := cases.RunResult{}
+		rr.Add(cases.Result{Name: "case 1", File: "tasks.json", Status: cases.StatusPassed, Duration: time.Second})
+		rr.Add(cases.Result{Name: "case 2", File: "tasks.json", Status: cases.StatusFailed, Duration: time.Second, Verdicts: []cases.Verdict{
+			{Field: "code", Valid: false, Expected: 200, Actual: 404},
+		}})
+		rr.Add(cases.Result{Name: "case 3", File: "users.json", Status: cases.StatusErrored, Err: errors.New("tester: request error")})
+		rr.Add(cases.Result{Name: "case 4", File: "users.json", Status: cases.StatusSkipped})
+		rr.Add(cases.Result{Name: "case 5", File: "users.json", Status: cases.StatusCanceled})
+		rr.Duration = 2 * time.Second
+
+		// act
+		err := rp.ReportRun(rr)
+
+		// assert
+		require.NoError(t, err)
+		b, err := os.ReadFile(path)
+		require.NoError(t, err)
+		expected := `<?xml version="1.0" encoding="UTF-8"?>
+<testsuites name="tester" tests="5" failures="1" errors="1" skipped="2" time="2.000">
+  <testsuite name="tasks.json" tests="2" failures="1" errors="0" skipped="0" time="2.000">
+    <testcase name="case 1" classname="tasks.json" time="1.000"></testcase>
+    <testcase name="case 2" classname="tasks.json" time="1.000">
+      <failure message="assertions failed">- expected code: 200&#xA;- actual code: 404&#xA;</failure>
+    </testcase>
+  </testsuite>
+  <testsuite name="users.json" tests="3" failures="0" errors="1" skipped="2" time="0.000">
+    <testcase name="case 3" classname="users.json" time="0.000">
+      <error message="tester: request error">tester: request error</error>
+    </testcase>
+    <testcase name="case 4" classname="users.json" time="0.000">
+      <skipped></skipped>
+    </testcase>
+    <testcase name="case 5" classname="users.json" time="0.000">
+      <skipped message="canceled"></skipped>
+    </testcase>
+  </testsuite>
+</testsuites>`
+		require.Equal(t, expected, string(b))
+	})
+
+	t.Run("case 2 - error writing the report", func(t *testing.T) {
+		// arrange
+		rp := cases.NewReporterJUnit(filepath.Join(t.TempDir(), "missing", "report.xml"))
+
+		// act
+		err := rp.ReportRun(cases.RunResult{})
+
+		// assert
+		require.ErrorIs(t, err, cases.ErrWriteReport)
+	})
+}
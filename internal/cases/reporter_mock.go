@@ -17,10 +17,39 @@ type ReporterMock struct {
 }
 
 // Report mocks base method.
-func (m *ReporterMock) Report(c *Case, resp *http.Response) (err error) {
+func (m *ReporterMock) Report(c *Case, resp *http.Response) (r Result, err error) {
 	args := m.Called(c, resp)
 
+	r = args.Get(0).(Result)
+	err = args.Error(1)
+
+	return
+}
+
+// NewResultReporterMock creates a new result reporter mock.
+func NewResultReporterMock() *ResultReporterMock {
+	return &ResultReporterMock{}
+}
+
+// ResultReporterMock is a mock of result reporter.
+type ResultReporterMock struct {
+	mock.Mock
+}
+
+// ReportResult mocks base method.
+func (m *ResultReporterMock) ReportResult(r Result) (err error) {
+	args := m.Called(r)
+
+	err = args.Error(0)
+
+	return
+}
+
+// ReportRun mocks base method.
+func (m *ResultReporterMock) ReportRun(rr RunResult) (err error) {
+	args := m.Called(rr)
+
 	err = args.Error(0)
 
 	return
-}
\ No newline at end of file
+}
@@ -1,9 +1,13 @@
 package cases
 
-import "net/http"
+import (
+	"context"
+	"net/http"
+)
 
 // Requester is the interface for cases's requests
 type Requester interface {
 	// Do makes the request.
-	Do(c *Case) (resp *http.Response, err error)
+	// - the request is canceled when the context is done
+	Do(ctx context.Context, c *Case) (resp *http.Response, err error)
 }
\ No newline at end of file
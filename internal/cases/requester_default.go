@@ -1,10 +1,9 @@
 package cases
 
 import (
-	"bytes"
-	"encoding/json"
-	"io"
+	"context"
 	"net/http"
+	"sort"
 )
 
 // NewRequesterDefault creates a new default requester.
@@ -34,26 +33,20 @@ type RequesterDefault struct {
 }
 
 // Do makes the request.
-func (r *RequesterDefault) Do(c *Case) (resp *http.Response, err error) {
+func (r *RequesterDefault) Do(ctx context.Context, c *Case) (resp *http.Response, err error) {
 	// request elements
 	// - method
 	method := c.Request.Method
 	// - url
 	url := r.serverAddr + c.Request.Path
 	// - body
-	var body io.Reader
-	if c.Request.Body != nil {
-		var b []byte
-		b, err = json.Marshal(c.Request.Body)
-		if err != nil {
-			return
-		}
-
-		body = bytes.NewReader(b)
+	body, contentType, err := encodeBody(c)
+	if err != nil {
+		return
 	}
 
 	// request
-	req, err := http.NewRequest(method, url, body)
+	req, err := http.NewRequestWithContext(ctx, method, url, body)
 	if err != nil {
 		return
 	}
@@ -64,9 +57,30 @@ func (r *RequesterDefault) Do(c *Case) (resp *http.Response, err error) {
 		q.Add(k, v)
 	}
 	req.URL.RawQuery = q.Encode()
-	// - headers
-	for k, v := range c.Request.Header {
-		req.Header.Set(k, v[0])
+	// - content type of the body, unless overridden by the headers
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	// - headers: every value of the case, canonicalized so that names differing in case are merged
+	//   > in sorted order of the names, so the order of the merged values is stable
+	names := make([]string, 0, len(c.Request.Header))
+	for k := range c.Request.Header {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+	header := make(http.Header, len(c.Request.Header))
+	for _, k := range names {
+		for _, hv := range c.Request.Header[k] {
+			header.Add(k, hv)
+		}
+	}
+	for k, v := range header {
+		// - host: sent by net/http from the request and not from its headers
+		if k == "Host" {
+			req.Host = v[0]
+			continue
+		}
+		req.Header[k] = v
 	}
 
 	// send
@@ -1,10 +1,15 @@
 package cases_test
 
 import (
+	"context"
 	"io"
+	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/LNMMusic/tester/internal/cases"
 
@@ -41,7 +46,7 @@ func TestRequesterDefault_Do(t *testing.T) {
 				},
 			},
 		}
-		resp, err := rq.Do(c)
+		resp, err := rq.Do(context.Background(), c)
 
 		// assert
 		require.NoError(t, err)
@@ -80,7 +85,7 @@ func TestRequesterDefault_Do(t *testing.T) {
 				},
 			},
 		}
-		resp, err := rq.Do(c)
+		resp, err := rq.Do(context.Background(), c)
 
 		// assert
 		require.NoError(t, err)
@@ -116,11 +121,169 @@ func TestRequesterDefault_Do(t *testing.T) {
 				},
 			},
 		}
-		resp, err := rq.Do(c)
+		resp, err := rq.Do(context.Background(), c)
 
 		// assert
 		require.Error(t, err)
 		require.EqualError(t, err, "Get \"invalid/?q1=v1\": unsupported protocol scheme \"\"")
 		require.Nil(t, resp)
 	})
-}
\ No newline at end of file
+
+	t.Run("case 4: success to make request - body types", func(t *testing.T) {
+		// arrange
+		// - server: echoes the content type and body
+		hd := func(w http.ResponseWriter, r *http.Request) {
+			b, _ := io.ReadAll(r.Body)
+			w.Header().Set("X-Content-Type", r.Header.Get("Content-Type"))
+			w.Write(b)
+		}
+		sv := httptest.NewServer(http.HandlerFunc(hd))
+		defer sv.Close()
+		// - requester
+		rq := cases.NewRequesterDefault(sv.URL, nil)
+
+		tcs := []struct {
+			name        string
+			request     cases.Request
+			contentType string
+			body        string
+		}{
+			{"json", cases.Request{Body: map[string]any{"k": 1.0}}, "application/json", `{"k":1}`},
+			{"form", cases.Request{BodyType: cases.BodyForm, Body: map[string]any{"user": "a b", "id": []any{1.0, 2.0}}}, "application/x-www-form-urlencoded", "id=1&id=2&user=a+b"},
+			{"text", cases.Request{BodyType: cases.BodyText, Body: "<note>hi</note>"}, "text/plain; charset=utf-8", "<note>hi</note>"},
+			{"binary", cases.Request{BodyType: cases.BodyBinary, Body: "AAEC"}, "application/octet-stream", "\x00\x01\x02"},
+			{"override", cases.Request{BodyType: cases.BodyText, Body: "<a/>", Header: http.Header{"content-type": {"application/xml"}}}, "application/xml", "<a/>"},
+		}
+		for _, tc := range tcs {
+			// act
+			tc.request.Method = http.MethodPost
+			resp, err := rq.Do(context.Background(), &cases.Case{Request: tc.request})
+
+			// assert
+			require.NoError(t, err, tc.name)
+			b, err := io.ReadAll(resp.Body)
+			require.NoError(t, err, tc.name)
+			require.Equal(t, tc.contentType, resp.Header.Get("X-Content-Type"), tc.name)
+			require.Equal(t, tc.body, string(b), tc.name)
+		}
+	})
+
+	t.Run("case 5: success to make request - multipart with files", func(t *testing.T) {
+		// arrange
+		// - file, relative to the file of the case
+		dir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "avatar.png"), []byte("png"), 0o644))
+		// - server: parses the form
+		var form *multipart.Form
+		hd := func(w http.ResponseWriter, r *http.Request) {
+			if err := r.ParseMultipartForm(1 << 20); err == nil {
+				form = r.MultipartForm
+			}
+			w.WriteHeader(http.StatusOK)
+		}
+		sv := httptest.NewServer(http.HandlerFunc(hd))
+		defer sv.Close()
+		// - requester
+		rq := cases.NewRequesterDefault(sv.URL, nil)
+
+		// act
+		_, err := rq.Do(context.Background(), &cases.Case{
+			File: filepath.Join(dir, "cases.json"),
+			Request: cases.Request{
+				Method:   http.MethodPost,
+				Path:     "/upload",
+				BodyType: cases.BodyMultipart,
+				Body: map[string]any{
+					"title":  "my avatar",
+					"avatar": map[string]any{"file": "avatar.png", "content_type": "image/png"},
+				},
+			},
+		})
+
+		// assert
+		require.NoError(t, err)
+		require.NotNil(t, form)
+		require.Equal(t, []string{"my avatar"}, form.Value["title"])
+		require.Len(t, form.File["avatar"], 1)
+		require.Equal(t, "avatar.png", form.File["avatar"][0].Filename)
+		require.Equal(t, "image/png", form.File["avatar"][0].Header.Get("Content-Type"))
+	})
+
+	t.Run("case 6: fail to make request - invalid body", func(t *testing.T) {
+		// arrange
+		rq := cases.NewRequesterDefault("", nil)
+
+		// act
+		_, err1 := rq.Do(context.Background(), &cases.Case{Request: cases.Request{BodyType: cases.BodyText, Body: 1.0}})
+		_, err2 := rq.Do(context.Background(), &cases.Case{Request: cases.Request{BodyType: cases.BodyMultipart, Body: map[string]any{"f": map[string]any{"file": "missing"}}}})
+		_, err3 := rq.Do(context.Background(), &cases.Case{Request: cases.Request{BodyType: "xml", Body: "<a/>"}})
+
+		// assert
+		require.ErrorIs(t, err1, cases.ErrInvalidBody)
+		require.ErrorIs(t, err2, cases.ErrInvalidBody)
+		require.ErrorIs(t, err3, cases.ErrUnknownBodyType)
+	})
+
+	t.Run("case 7: success to make request - multi-valued headers and host", func(t *testing.T) {
+		// arrange
+		// - server: mock
+		var header http.Header
+		var host string
+		hd := func(w http.ResponseWriter, r *http.Request) {
+			header, host = r.Header, r.Host
+			w.WriteHeader(http.StatusOK)
+		}
+		sv := httptest.NewServer(http.HandlerFunc(hd))
+		defer sv.Close()
+		// - requester
+		rq := cases.NewRequesterDefault(sv.URL, nil)
+
+		// act
+		c := &cases.Case{
+			Request: cases.Request{
+				Method: http.MethodPost,
+				Path:   "/",
+				Body:   map[string]any{"b1": "v1"},
+				Header: http.Header{
+					"accept":       []string{"application/xml", "application/json;q=0.5"},
+					"Accept":       []string{"text/plain"},
+					"Cookie":       []string{"a=1", "b=2"},
+					"content-type": []string{"application/vnd.api+json"},
+					"host":         []string{"api.example.com"},
+					"X-Empty":      []string{},
+				},
+			},
+		}
+		resp, err := rq.Do(context.Background(), c)
+
+		// assert
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		require.Equal(t, []string{"text/plain", "application/xml", "application/json;q=0.5"}, header.Values("Accept"))
+		require.Equal(t, []string{"a=1", "b=2"}, header.Values("Cookie"))
+		require.Equal(t, []string{"application/vnd.api+json"}, header.Values("Content-Type"))
+		require.Empty(t, header.Values("X-Empty"))
+		require.Equal(t, "api.example.com", host)
+	})
+
+	t.Run("case 8: fail to make request - context deadline exceeded", func(t *testing.T) {
+		// arrange
+		// - server: mock, hangs until the request is canceled
+		hd := func(w http.ResponseWriter, r *http.Request) {
+			<-r.Context().Done()
+		}
+		sv := httptest.NewServer(http.HandlerFunc(hd))
+		defer sv.Close()
+		// - requester
+		rq := cases.NewRequesterDefault(sv.URL, nil)
+		// - context
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		// act
+		_, err := rq.Do(ctx, &cases.Case{Request: cases.Request{Method: http.MethodGet, Path: "/"}})
+
+		// assert
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+}
@@ -1,6 +1,7 @@
 package cases
 
 import (
+	"context"
 	"net/http"
 
 	"github.com/stretchr/testify/mock"
@@ -17,8 +18,8 @@ type RequesterMock struct {
 }
 
 // Do mocks base method.
-func (m *RequesterMock) Do(c *Case) (resp *http.Response, err error) {
-	args := m.Called(c)
+func (m *RequesterMock) Do(ctx context.Context, c *Case) (resp *http.Response, err error) {
+	args := m.Called(ctx, c)
 
 	resp = args.Get(0).(*http.Response)
 	err = args.Error(1)
@@ -0,0 +1,270 @@
+package cases
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"os"
+	"strings"
+)
+
+const (
+	// BodyXML is a body compared as XML, regardless of whitespace between elements and the order of attributes.
+	BodyXML BodyType = "xml"
+	// BodyEmpty is a body that must be empty (e.g. 204 No Content).
+	BodyEmpty BodyType = "empty"
+)
+
+var (
+	// ErrMalformedXML is the error returned when the expected XML is malformed.
+	ErrMalformedXML = errors.New("malformed xml")
+)
+
+// responseBodyType returns the body type used to compare the body of a response.
+// - the body type of the case has precedence, otherwise it is picked from the content type
+// - empty bodies are decoded as null, so they match an absent expected body
+func responseBodyType(bt BodyType, contentType string, raw []byte) BodyType {
+	if bt != "" {
+		return bt
+	}
+	if len(raw) == 0 {
+		return BodyJSON
+	}
+
+	mt, _, _ := mime.ParseMediaType(contentType)
+	switch {
+	case mt == "", mt == "application/json", strings.HasSuffix(mt, "+json"):
+		return BodyJSON
+	case mt == "application/xml", mt == "text/xml", strings.HasSuffix(mt, "+xml"):
+		return BodyXML
+	case strings.HasPrefix(mt, "text/"):
+		return BodyText
+	default:
+		return BodyBinary
+	}
+}
+
+// diffBody compares the expected body of a test case against the raw body of its response.
+// - actual is the decoded body, as recorded in the reports
+func diffBody(c *Case, bt BodyType, raw []byte, match MatchMode) (actual any, v Verdict, err error) {
+	expected := c.Response.Body
+	v = Verdict{Field: "body", Expected: expected}
+
+	var d []Difference
+	switch bt {
+	case BodyJSON:
+		if len(raw) > 0 {
+			err = json.NewDecoder(bytes.NewReader(raw)).Decode(&actual)
+			if err != nil {
+				return
+			}
+		}
+		d, err = DiffMatch(expected, actual, match)
+	case BodyText:
+		// - exact text, or a matcher such as {"$regex": "..."}
+		actual = string(raw)
+		d, err = DiffMatch(expected, actual, MatchExact)
+	case BodyXML:
+		actual = string(raw)
+		d, err = diffXML(expected, raw)
+	case BodyEmpty:
+		actual = string(raw)
+		if len(raw) > 0 {
+			d = []Difference{{Kind: DiffChanged, Expected: "", Actual: actual, Message: "body is not empty"}}
+		}
+		v.Expected = ""
+	case BodyBinary:
+		sum := sha256.Sum256(raw)
+		actual = map[string]any{"size": float64(len(raw)), "sha256": hex.EncodeToString(sum[:])}
+		// - no expected body: the body must be empty, as with the other body types
+		if expected == nil {
+			if len(raw) > 0 {
+				d = []Difference{{Kind: DiffType, Expected: nil, Actual: actual}}
+			}
+			break
+		}
+		var want string
+		want, err = expectedHash(c, expected)
+		if err != nil {
+			return
+		}
+		if want != "" && !strings.EqualFold(want, hex.EncodeToString(sum[:])) {
+			d = []Difference{{Path: "/sha256", Kind: DiffChanged, Expected: want, Actual: hex.EncodeToString(sum[:])}}
+		}
+	default:
+		err = fmt.Errorf("%w - %s", ErrUnknownBodyType, bt)
+	}
+	if err != nil {
+		return
+	}
+
+	v.Valid, v.Actual, v.Diff = len(d) == 0, actual, d
+	return
+}
+
+// expectedHash returns the sha256 of an expected binary body: a base64 string, a {"sha256": hex} object
+// or a {"file": path} object.
+func expectedHash(c *Case, expected any) (hash string, err error) {
+	var b []byte
+	switch e := expected.(type) {
+	case nil:
+		return
+	case map[string]any:
+		if h, ok := e["sha256"].(string); ok {
+			hash = h
+			return
+		}
+		path, _ := e["file"].(string)
+		if path == "" {
+			err = fmt.Errorf("%w - binary body requires a sha256 or a file", ErrInvalidBody)
+			return
+		}
+		b, err = os.ReadFile(c.Resolve(path))
+		if err != nil {
+			err = fmt.Errorf("%w - %v", ErrInvalidBody, err)
+			return
+		}
+	case string:
+		b, err = base64.StdEncoding.DecodeString(e)
+		if err != nil {
+			err = fmt.Errorf("%w - binary body must be base64: %v", ErrInvalidBody, err)
+			return
+		}
+	default:
+		err = fmt.Errorf("%w - binary body must be a base64 string, a sha256 or a file", ErrInvalidBody)
+		return
+	}
+
+	sum := sha256.Sum256(b)
+	hash = hex.EncodeToString(sum[:])
+	return
+}
+
+// diffXML compares an expected XML document against the actual one.
+// - both are decoded as trees of {"name", "attributes", "text", "children"} objects, so the differences have paths
+// - an actual body that is not XML is reported as a type mismatch
+func diffXML(expected any, raw []byte) (d []Difference, err error) {
+	s, ok := expected.(string)
+	if !ok {
+		if expected == nil {
+			return DiffMatch(nil, string(raw), MatchExact)
+		}
+		err = fmt.Errorf("%w - expected xml body must be a string", ErrMalformedXML)
+		return
+	}
+	e, err := decodeXML([]byte(s))
+	if err != nil {
+		err = fmt.Errorf("%w - %v", ErrMalformedXML, err)
+		return
+	}
+	a, e2 := decodeXML(raw)
+	if e2 != nil {
+		d = []Difference{{Kind: DiffType, Expected: e, Actual: string(raw)}}
+		return
+	}
+	return DiffMatch(e, a, MatchExact)
+}
+
+// decodeXML decodes the root element of an XML document as a tree.
+// - the text of an element without children is trimmed, as is the whitespace between elements
+// - mixed content (text along with elements) is kept as is, as strings among the children in document order
+func decodeXML(raw []byte) (root any, err error) {
+	dec := xml.NewDecoder(bytes.NewReader(raw))
+	var stack []map[string]any
+	for {
+		var tok xml.Token
+		tok, err = dec.Token()
+		if err == io.EOF {
+			err = nil
+			break
+		}
+		if err != nil {
+			return
+		}
+
+		switch t := tok.(type) {
+		case xml.StartElement:
+			attrs := make(map[string]any, len(t.Attr))
+			for _, a := range t.Attr {
+				attrs[xmlName(a.Name)] = a.Value
+			}
+			el := map[string]any{"name": xmlName(t.Name), "attributes": attrs, "text": "", "children": []any{}}
+			if len(stack) > 0 {
+				parent := stack[len(stack)-1]
+				parent["children"] = append(parent["children"].([]any), el)
+			}
+			stack = append(stack, el)
+		case xml.EndElement:
+			el := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			xmlText(el)
+			if len(stack) == 0 {
+				root = el
+			}
+		case xml.CharData:
+			if len(stack) > 0 {
+				el := stack[len(stack)-1]
+				children := el["children"].([]any)
+				if n := len(children); n > 0 {
+					if s, ok := children[n-1].(string); ok {
+						children[n-1] = s + string(t)
+						continue
+					}
+				}
+				el["children"] = append(children, string(t))
+			}
+		}
+	}
+	if root == nil {
+		err = errors.New("missing root element")
+	}
+	return
+}
+
+// xmlText sets the text of a decoded element out of the strings among its children.
+func xmlText(el map[string]any) {
+	var texts, elements []any
+	mixed := false
+	for _, c := range el["children"].([]any) {
+		s, ok := c.(string)
+		if !ok {
+			elements = append(elements, c)
+			continue
+		}
+		texts = append(texts, s)
+		if strings.TrimSpace(s) != "" {
+			mixed = true
+		}
+	}
+
+	switch {
+	// - leaf: its trimmed text
+	case len(elements) == 0:
+		var sb strings.Builder
+		for _, s := range texts {
+			sb.WriteString(s.(string))
+		}
+		el["text"] = strings.TrimSpace(sb.String())
+		el["children"] = []any{}
+	// - mixed content: the strings stay among the children
+	case mixed:
+	// - elements only: the whitespace between them is formatting
+	default:
+		el["children"] = elements
+	}
+}
+
+// xmlName formats the name of an element or attribute, with its namespace if any.
+func xmlName(n xml.Name) string {
+	if n.Space == "" {
+		return n.Local
+	}
+	return n.Space + ":" + n.Local
+}
@@ -0,0 +1,228 @@
+package cases
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Status is the status of a test case once it has been processed.
+type Status string
+
+const (
+	// StatusPassed is the status of a test case whose assertions passed.
+	StatusPassed Status = "passed"
+	// StatusFailed is the status of a test case whose assertions failed.
+	StatusFailed Status = "failed"
+	// StatusErrored is the status of a test case that could not be run.
+	StatusErrored Status = "errored"
+	// StatusSkipped is the status of a test case that was not run.
+	StatusSkipped Status = "skipped"
+	// StatusTimeout is the status of a test case that did not complete within its timeout.
+	StatusTimeout Status = "timeout"
+	// StatusCanceled is the status of a test case that did not start before the run was interrupted.
+	StatusCanceled Status = "canceled"
+)
+
+// label returns the label of the status printed in the reports.
+func (s Status) label() string {
+	switch s {
+	case StatusPassed:
+		return "PASS"
+	case StatusFailed:
+		return "FAIL"
+	case StatusErrored:
+		return "ERROR"
+	case StatusSkipped:
+		return "SKIP"
+	case StatusTimeout:
+		return "TIMEOUT"
+	case StatusCanceled:
+		return "CANCELED"
+	}
+	return strings.ToUpper(string(s))
+}
+
+// Verdict is the verdict of an asserted field of a test case.
+type Verdict struct {
+	// Field is the name of the asserted field (e.g. code, body, header).
+	Field string `json:"field"`
+	// Valid is true if the actual value matches the expected one.
+	Valid bool `json:"valid"`
+	// Expected is the expected value of the field.
+	Expected any `json:"expected"`
+	// Actual is the actual value of the field.
+	Actual any `json:"actual"`
+	// Diff is the set of differences between the expected and actual values, if structured.
+	Diff []Difference `json:"diff,omitempty"`
+}
+
+// SentRequest is the request sent to the server for a test case.
+type SentRequest struct {
+	// Method is the HTTP method of the request.
+	Method string `json:"method"`
+	// URL is the full URL of the request, including the query.
+	URL string `json:"url"`
+	// Host is the host the request was sent to, which differs from the host of the URL if overridden.
+	Host string `json:"host,omitempty"`
+	// Header is the set of headers of the request.
+	Header http.Header `json:"header"`
+	// Body is the body of the request.
+	Body any `json:"body"`
+}
+
+// ReceivedResponse is the response received from the server for a test case.
+type ReceivedResponse struct {
+	// Code is the status code of the response.
+	Code int `json:"code"`
+	// Header is the set of headers of the response.
+	Header http.Header `json:"header"`
+	// Body is the decoded body of the response.
+	Body any `json:"body"`
+	// Elapsed is the time it took to receive the response.
+	Elapsed time.Duration `json:"-"`
+}
+
+// Result is the result of a test case.
+type Result struct {
+	// Name is the name of the test case.
+	Name string
+	// File is the file the test case was read from.
+	File string
+	// Status is the status of the test case.
+	Status Status
+	// Verdicts is the set of verdicts of the asserted fields.
+	Verdicts []Verdict
+	// Err is the error that prevented the test case from running.
+	Err error
+	// Request is the request sent to the server.
+	Request *SentRequest
+	// Response is the response received from the server.
+	Response *ReceivedResponse
+	// Expected is the expected response.
+	Expected *Response
+	// Captured is the set of variables captured from the response.
+	Captured map[string]any
+	// Steps is the set of results of the steps of a scenario, in the order they were run.
+	Steps []Result
+	// Started is the time the test case started.
+	Started time.Time
+	// Duration is the time it took to process the test case.
+	Duration time.Duration
+}
+
+// NewResult creates a new result out of a set of verdicts.
+// - the status is passed if every verdict is valid, failed otherwise
+func NewResult(name string, verdicts ...Verdict) (r Result) {
+	r = Result{
+		Name:     name,
+		Status:   StatusPassed,
+		Verdicts: verdicts,
+	}
+	for _, v := range verdicts {
+		if !v.Valid {
+			r.Status = StatusFailed
+			break
+		}
+	}
+	return
+}
+
+// AddVerdicts adds a set of verdicts to the result, failing it if any of them is invalid.
+func (r *Result) AddVerdicts(verdicts ...Verdict) {
+	r.Verdicts = append(r.Verdicts, verdicts...)
+	for _, v := range verdicts {
+		if !v.Valid && r.Status == StatusPassed {
+			r.Status = StatusFailed
+		}
+	}
+}
+
+// Failure returns the description of the invalid verdicts of the result.
+func (r Result) Failure() string {
+	return r.failure(false)
+}
+
+// failure returns the description of the invalid verdicts of the result, optionally colored.
+// - verdicts with structured differences are described by their diff
+func (r Result) failure(color bool) string {
+	var sb strings.Builder
+	sb.WriteString(r.steps(color))
+	for _, v := range r.Verdicts {
+		if v.Valid {
+			continue
+		}
+		if len(v.Diff) > 0 {
+			fmt.Fprintf(&sb, "- %s diff (- expected, + actual):\n", v.Field)
+			sb.WriteString(FormatDiff(v.Diff, color))
+			continue
+		}
+		fmt.Fprintf(&sb, "- expected %s: %v\n", v.Field, v.Expected)
+		fmt.Fprintf(&sb, "- actual %s: %v\n", v.Field, v.Actual)
+	}
+	return sb.String()
+}
+
+// steps returns the description of the steps of a scenario, if any.
+// - failed steps are followed by the description of their invalid verdicts,
+//   the error of an errored step is the error of the scenario
+func (r Result) steps(color bool) string {
+	var sb strings.Builder
+	for _, s := range r.Steps {
+		fmt.Fprintf(&sb, "- step '%s': %s\n", s.Name, s.Status.label())
+		if s.Status == StatusFailed {
+			sb.WriteString(s.failure(color))
+		}
+	}
+	return sb.String()
+}
+
+// RunResult is the aggregated result of a run of test cases.
+type RunResult struct {
+	// Passed is the number of passed test cases.
+	Passed int
+	// Failed is the number of failed test cases.
+	Failed int
+	// Errored is the number of test cases that could not be run.
+	Errored int
+	// Skipped is the number of skipped test cases.
+	Skipped int
+	// TimedOut is the number of test cases that did not complete within their timeout.
+	TimedOut int
+	// Canceled is the number of test cases that did not start before the run was interrupted.
+	Canceled int
+	// Results is the set of results of each test case, in the order they were read.
+	Results []Result
+	// Duration is the time it took to process the whole run.
+	Duration time.Duration
+}
+
+// Add adds the result of a test case to the run.
+func (rr *RunResult) Add(r Result) {
+	switch r.Status {
+	case StatusPassed:
+		rr.Passed++
+	case StatusFailed:
+		rr.Failed++
+	case StatusErrored:
+		rr.Errored++
+	case StatusSkipped:
+		rr.Skipped++
+	case StatusTimeout:
+		rr.TimedOut++
+	case StatusCanceled:
+		rr.Canceled++
+	}
+	rr.Results = append(rr.Results, r)
+}
+
+// Total returns the number of test cases of the run.
+func (rr *RunResult) Total() int {
+	return rr.Passed + rr.Failed + rr.Errored + rr.Skipped + rr.TimedOut + rr.Canceled
+}
+
+// Ok returns true if no test case failed, errored nor timed out.
+func (rr *RunResult) Ok() bool {
+	return rr.Failed == 0 && rr.Errored == 0 && rr.TimedOut == 0
+}
@@ -0,0 +1,306 @@
+package cases
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"sync"
+)
+
+var (
+	// ErrUnknownVariable is the error returned when a placeholder references an unknown variable.
+	ErrUnknownVariable = errors.New("unknown variable")
+	// ErrHeaderNotFound is the error returned when a captured header is not present.
+	ErrHeaderNotFound = errors.New("header not found")
+)
+
+// Capture is the set of values of the response captured into variables.
+type Capture struct {
+	// Body maps a JSONPath expression of the response body to the name of a variable (e.g. "$.data.id": "task_id").
+	Body map[string]string `json:"body"`
+	// Header maps the name of a response header to the name of a variable (e.g. "Location": "task_url").
+	Header map[string]string `json:"header"`
+}
+
+// Captures returns the sorted set of names of the variables captured by a test case, including its steps.
+func (c *Case) Captures() (names []string) {
+	set := make(map[string]bool)
+	add := func(cp Capture) {
+		for _, name := range cp.Body {
+			set[name] = true
+		}
+		for _, name := range cp.Header {
+			set[name] = true
+		}
+	}
+	add(c.Capture)
+	for _, s := range c.Scenario.Steps {
+		add(s.Capture)
+	}
+	return sortedSet(set)
+}
+
+// References returns the sorted set of names of the variables referenced by the placeholders of a test case.
+func (c *Case) References() (names []string) {
+	b, err := json.Marshal(c)
+	if err != nil {
+		return
+	}
+	set := make(map[string]bool)
+	for _, m := range placeholder.FindAllStringSubmatch(string(b), -1) {
+		set[m[1]] = true
+	}
+	return sortedSet(set)
+}
+
+// sortedSet returns the sorted elements of a set.
+func sortedSet(set map[string]bool) (s []string) {
+	for e := range set {
+		s = append(s, e)
+	}
+	sort.Strings(s)
+	return
+}
+
+// NewVariables creates a new set of variables.
+func NewVariables() *Variables {
+	return &Variables{
+		values: make(map[string]any),
+	}
+}
+
+// Variables is a set of variables captured during a run, safe for concurrent use.
+type Variables struct {
+	// mu guards the values.
+	mu sync.RWMutex
+	// values is the set of values by name.
+	values map[string]any
+}
+
+// Set sets the value of a variable.
+func (v *Variables) Set(name string, value any) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.values[name] = value
+}
+
+// Get returns the value of a variable.
+func (v *Variables) Get(name string) (value any, ok bool) {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	value, ok = v.values[name]
+	return
+}
+
+// Capture captures the values of a response into variables.
+// - body is the decoded response body
+// - captured is the set of values captured by name, even if err is returned
+func (v *Variables) Capture(cp Capture, body any, header http.Header) (captured map[string]any, err error) {
+	captured = make(map[string]any)
+
+	// body: sorted so that the first error is deterministic
+	paths := make([]string, 0, len(cp.Body))
+	for p := range cp.Body {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+	for _, p := range paths {
+		var value any
+		value, err = LookupJSONPath(body, p)
+		if err != nil {
+			return
+		}
+		captured[cp.Body[p]] = value
+	}
+
+	// header: sorted so that the first error is deterministic
+	names := make([]string, 0, len(cp.Header))
+	for h := range cp.Header {
+		names = append(names, h)
+	}
+	sort.Strings(names)
+	for _, h := range names {
+		name := cp.Header[h]
+		values := header.Values(h)
+		if len(values) == 0 {
+			err = fmt.Errorf("%w - %s", ErrHeaderNotFound, h)
+			return
+		}
+		captured[name] = values[0]
+	}
+
+	for name, value := range captured {
+		v.Set(name, value)
+	}
+	return
+}
+
+// placeholder is the pattern of a reference to a variable, e.g. {{ task_id }}.
+var placeholder = regexp.MustCompile(`\{\{\s*([A-Za-z_][A-Za-z0-9_.-]*)\s*\}\}`)
+
+// Interpolate returns a copy of a test case with its placeholders replaced by the values of the variables.
+// - placeholders are replaced in the path, query, headers and body of the request,
+//   the body and headers of the expected response, the set-up and tear-down queries
+//   and the queries and rows of the database expectations
+// - a string that is a single placeholder is replaced by the raw value (e.g. a number stays a number)
+func (v *Variables) Interpolate(c *Case) (ic Case, err error) {
+	ic = *c
+
+	// database
+	if ic.Database.SetUp, err = v.interpolateStrings(c.Database.SetUp); err != nil {
+		return
+	}
+	if ic.Database.TearDown, err = v.interpolateStrings(c.Database.TearDown); err != nil {
+		return
+	}
+
+	// request
+	if ic.Request.Path, err = v.interpolateString(c.Request.Path); err != nil {
+		return
+	}
+	if c.Request.Query != nil {
+		ic.Request.Query = make(map[string]string, len(c.Request.Query))
+		for k, q := range c.Request.Query {
+			if ic.Request.Query[k], err = v.interpolateString(q); err != nil {
+				return
+			}
+		}
+	}
+	if c.Request.Header != nil {
+		ic.Request.Header = make(http.Header, len(c.Request.Header))
+		for k, h := range c.Request.Header {
+			if ic.Request.Header[k], err = v.interpolateStrings(h); err != nil {
+				return
+			}
+		}
+	}
+	if ic.Request.Body, err = v.interpolateValue(c.Request.Body); err != nil {
+		return
+	}
+
+	// response
+	if ic.Response.Body, err = v.interpolateValue(c.Response.Body); err != nil {
+		return
+	}
+	if c.Response.Header != nil {
+		ic.Response.Header = make(map[string]any, len(c.Response.Header))
+		for k, h := range c.Response.Header {
+			if ic.Response.Header[k], err = v.interpolateValue(h); err != nil {
+				return
+			}
+		}
+	}
+
+	// database expectations
+	if c.ExpectDatabase != nil {
+		ic.ExpectDatabase = make([]DatabaseExpectation, len(c.ExpectDatabase))
+		for i, ex := range c.ExpectDatabase {
+			ic.ExpectDatabase[i] = ex
+			if ic.ExpectDatabase[i].Query, err = v.interpolateString(ex.Query); err != nil {
+				return
+			}
+			if ex.Rows == nil {
+				continue
+			}
+			ic.ExpectDatabase[i].Rows = make([]map[string]any, len(ex.Rows))
+			for j, row := range ex.Rows {
+				var ir any
+				if ir, err = v.interpolateValue(map[string]any(row)); err != nil {
+					return
+				}
+				ic.ExpectDatabase[i].Rows[j] = ir.(map[string]any)
+			}
+		}
+	}
+
+	return
+}
+
+// interpolateValue replaces the placeholders of the strings of a decoded JSON value.
+func (v *Variables) interpolateValue(value any) (any, error) {
+	switch val := value.(type) {
+	case string:
+		// - single placeholder: raw value
+		if m := placeholder.FindStringSubmatchIndex(val); m != nil && m[0] == 0 && m[1] == len(val) {
+			name := val[m[2]:m[3]]
+			raw, ok := v.Get(name)
+			if !ok {
+				return nil, fmt.Errorf("%w - %s", ErrUnknownVariable, name)
+			}
+			return raw, nil
+		}
+		return v.interpolateString(val)
+	case map[string]any:
+		obj := make(map[string]any, len(val))
+		for k, e := range val {
+			ie, err := v.interpolateValue(e)
+			if err != nil {
+				return nil, err
+			}
+			obj[k] = ie
+		}
+		return obj, nil
+	case []any:
+		arr := make([]any, len(val))
+		for i, e := range val {
+			ie, err := v.interpolateValue(e)
+			if err != nil {
+				return nil, err
+			}
+			arr[i] = ie
+		}
+		return arr, nil
+	default:
+		return value, nil
+	}
+}
+
+// interpolateStrings replaces the placeholders of a set of strings.
+func (v *Variables) interpolateStrings(s []string) (is []string, err error) {
+	if s == nil {
+		return
+	}
+	is = make([]string, len(s))
+	for i := range s {
+		if is[i], err = v.interpolateString(s[i]); err != nil {
+			return
+		}
+	}
+	return
+}
+
+// interpolateString replaces the placeholders of a string by the formatted values of the variables.
+func (v *Variables) interpolateString(s string) (is string, err error) {
+	is = placeholder.ReplaceAllStringFunc(s, func(m string) string {
+		name := placeholder.FindStringSubmatch(m)[1]
+		value, ok := v.Get(name)
+		if !ok {
+			if err == nil {
+				err = fmt.Errorf("%w - %s", ErrUnknownVariable, name)
+			}
+			return m
+		}
+		return formatVariable(value)
+	})
+	return
+}
+
+// formatVariable formats the value of a variable to be embedded in a string.
+func formatVariable(value any) string {
+	switch val := value.(type) {
+	case string:
+		return val
+	case float64:
+		return strconv.FormatFloat(val, 'f', -1, 64)
+	case nil:
+		return "null"
+	case bool, int, int64:
+		return fmt.Sprint(val)
+	default:
+		return compactJSON(val)
+	}
+}
@@ -0,0 +1,136 @@
+package cases_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/LNMMusic/tester/internal/cases"
+
+	"github.com/stretchr/testify/require"
+)
+
+// Tests for Variables.Capture
+func TestVariables_Capture(t *testing.T) {
+	t.Run("case 1 - capture body and header", func(t *testing.T) {
+		// arrange
+		v := cases.NewVariables()
+		cp := cases.Capture{
+			Body:   map[string]string{"$.data.id": "task_id"},
+			Header: map[string]string{"location": "task_url"},
+		}
+		body := map[string]any{"data": map[string]any{"id": 7.0}}
+		header := http.Header{"Location": {"/tasks/7"}}
+
+		// act
+		captured, err := v.Capture(cp, body, header)
+
+		// assert
+		require.NoError(t, err)
+		require.Equal(t, map[string]any{"task_id": 7.0, "task_url": "/tasks/7"}, captured)
+		id, ok := v.Get("task_id")
+		require.True(t, ok)
+		require.Equal(t, 7.0, id)
+	})
+
+	t.Run("case 2 - error missing header", func(t *testing.T) {
+		// arrange
+		v := cases.NewVariables()
+		cp := cases.Capture{Header: map[string]string{"Location": "task_url"}}
+
+		// act
+		_, err := v.Capture(cp, nil, http.Header{})
+
+		// assert
+		require.ErrorIs(t, err, cases.ErrHeaderNotFound)
+		_, ok := v.Get("task_url")
+		require.False(t, ok)
+	})
+
+	t.Run("case 3 - error first missing header in order of the names", func(t *testing.T) {
+		// arrange
+		v := cases.NewVariables()
+		cp := cases.Capture{Header: map[string]string{"X-C": "c", "X-A": "a", "X-B": "b"}}
+
+		for i := 0; i < 10; i++ {
+			// act
+			_, err := v.Capture(cp, nil, http.Header{"X-B": {"b"}})
+
+			// assert
+			require.EqualError(t, err, "header not found - X-A")
+		}
+	})
+}
+
+// Tests for Case Captures and References
+func TestCase_Variables(t *testing.T) {
+	t.Run("case 1 - captured and referenced variables of a case and its steps", func(t *testing.T) {
+		// arrange
+		c := &cases.Case{
+			Request:  cases.Request{Path: "/tasks/{{ task_id }}", Header: http.Header{"Authorization": {"Bearer {{token}}"}}},
+			Response: cases.Response{Body: map[string]any{"id": "{{ task_id }}"}},
+			Capture:  cases.Capture{Header: map[string]string{"Location": "task_url"}},
+			Scenario: cases.Scenario{Steps: []cases.Step{
+				{Request: cases.Request{Path: "{{ task_url }}"}, Capture: cases.Capture{Body: map[string]string{"$.id": "step_id"}}},
+			}},
+		}
+
+		// act
+		captures := c.Captures()
+		references := c.References()
+
+		// assert
+		require.Equal(t, []string{"step_id", "task_url"}, captures)
+		require.Equal(t, []string{"task_id", "task_url", "token"}, references)
+	})
+}
+
+// Tests for Variables.Interpolate
+func TestVariables_Interpolate(t *testing.T) {
+	t.Run("case 1 - interpolate request, response and database", func(t *testing.T) {
+		// arrange
+		v := cases.NewVariables()
+		v.Set("task_id", 7.0)
+		v.Set("token", "abc")
+		c := &cases.Case{
+			Name: "get task",
+			Database: cases.Database{
+				TearDown: []string{"DELETE FROM tasks WHERE id = {{ task_id }}"},
+			},
+			Request: cases.Request{
+				Method: "GET",
+				Path:   "/tasks/{{task_id}}",
+				Query:  map[string]string{"token": "{{ token }}"},
+				Header: http.Header{"Authorization": {"Bearer {{ token }}"}},
+			},
+			Response: cases.Response{
+				Code: 200,
+				Body: map[string]any{"id": "{{ task_id }}", "ref": "task-{{ task_id }}"},
+			},
+		}
+
+		// act
+		ic, err := v.Interpolate(c)
+
+		// assert
+		require.NoError(t, err)
+		require.Equal(t, []string{"DELETE FROM tasks WHERE id = 7"}, ic.Database.TearDown)
+		require.Equal(t, "/tasks/7", ic.Request.Path)
+		require.Equal(t, map[string]string{"token": "abc"}, ic.Request.Query)
+		require.Equal(t, http.Header{"Authorization": {"Bearer abc"}}, ic.Request.Header)
+		require.Equal(t, map[string]any{"id": 7.0, "ref": "task-7"}, ic.Response.Body)
+		// - the original case is left untouched
+		require.Equal(t, "/tasks/{{task_id}}", c.Request.Path)
+	})
+
+	t.Run("case 2 - error unknown variable", func(t *testing.T) {
+		// arrange
+		v := cases.NewVariables()
+		c := &cases.Case{Request: cases.Request{Path: "/tasks/{{ task_id }}"}}
+
+		// act
+		_, err := v.Interpolate(c)
+
+		// assert
+		require.ErrorIs(t, err, cases.ErrUnknownVariable)
+	})
+}
@@ -1,6 +1,7 @@
 package internal
 
 import (
+	"context"
 	"errors"
 
 	"github.com/LNMMusic/tester/internal/cases"
@@ -14,10 +15,21 @@ var (
 	ErrTesterRequest = errors.New("tester: request error")
 	// ErrTesterReporter is the error of the reporter.
 	ErrTesterReporter = errors.New("tester: reporter error")
+	// ErrTesterVariables is the error of the interpolation of variables.
+	ErrTesterVariables = errors.New("tester: variables error")
+	// ErrTesterValidation is the error of a case that can not be run.
+	ErrTesterValidation = errors.New("tester: validation error")
+	// ErrTesterIsolation is the error of the isolation of the database.
+	ErrTesterIsolation = errors.New("tester: isolation error")
+	// ErrTesterScenario is the error of a step of a scenario.
+	ErrTesterScenario = errors.New("tester: scenario error")
+	// ErrTesterTimeout is the error of a case that did not complete within its timeout.
+	ErrTesterTimeout = errors.New("tester: timeout error")
 )
 
 // CaseTester is an interface that test a case.
 type CaseTester interface {
 	// Test tests a case.
-	Test(c *cases.Case) (err error)
+	// - err is returned when the case could not be run, wrapping ErrTesterTimeout if it timed out
+	Test(ctx context.Context, c *cases.Case) (r cases.Result, err error)
 }
\ No newline at end of file
@@ -1,18 +1,27 @@
 package internal
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/LNMMusic/tester/internal/cases"
 )
 
 // NewCaseTesterDefault creates a new case tester.
-func NewCaseTesterDefault(dbExecuter cases.DbExecuter, requester cases.Requester, reporter cases.Reporter) *CaseTesterDefault {
+// - isolator is optional, nil to leave the state of the database to the set-up and tear-down of each case
+// - timeout is the default maximum time to run each case, 0 for no timeout
+func NewCaseTesterDefault(dbExecuter cases.DbExecuter, requester cases.Requester, reporter cases.Reporter, isolator cases.Isolator, timeout time.Duration) *CaseTesterDefault {
 	return &CaseTesterDefault{
 		dbExecuter: dbExecuter,
 		requester: requester,
 		reporter: reporter,
+		isolator: isolator,
+		timeout: timeout,
+		variables: cases.NewVariables(),
+		fixtures: cases.NewFixtureLoader(),
 	}
 }
 
@@ -24,15 +33,81 @@ type CaseTesterDefault struct {
 	requester cases.Requester
 	// reporter is the reporter of test cases.
 	reporter cases.Reporter
+	// isolator is the isolator of the state of the database of test cases.
+	isolator cases.Isolator
+	// timeout is the default maximum time to run a case, overridden by the timeout of the case.
+	timeout time.Duration
+	// variables is the set of variables captured from the responses, shared by the following cases.
+	variables *cases.Variables
+	// fixtures is the loader of the fixtures and sql files, shared by every case.
+	fixtures *cases.FixtureLoader
 }
 
 // Test tests the server.
-func (t *CaseTesterDefault) Test(c *cases.Case) (err error) {
+func (t *CaseTesterDefault) Test(ctx context.Context, c *cases.Case) (r cases.Result, err error) {
 	// arrange
+	// - variables: interpolation
+	//   > the database expectations of a scenario are interpolated after its steps, as they may reference their captures
+	pc := *c
+	if len(c.Scenario.Steps) > 0 {
+		pc.ExpectDatabase = nil
+	}
+	ic, err := t.variables.Interpolate(&pc)
+	if err != nil {
+		err = fmt.Errorf("%w. %v", ErrTesterVariables, err)
+		return
+	}
+	ic.ExpectDatabase = c.ExpectDatabase
+	c = &ic
+	// - database: validation
+	if v, ok := t.dbExecuter.(cases.DbValidator); ok {
+		err = v.Validate(c)
+		if err != nil {
+			err = fmt.Errorf("%w. %v", ErrTesterValidation, err)
+			return
+		}
+	}
+	// - timeout
+	timeout := time.Duration(c.Timeout)
+	if timeout == 0 {
+		timeout = t.timeout
+	}
+	// - database: isolation
+	//   > the wait for other cases is canceled only with the run, the case deadline starts once the database is acquired
+	if t.isolator != nil {
+		err = t.isolator.Save(ctx)
+		if err != nil {
+			err = fmt.Errorf("%w. %v", ErrTesterIsolation, err)
+			return
+		}
+		defer func() {
+			cleanupCtx, cancel := cleanupContext(ctx, timeout)
+			defer cancel()
+			e := t.isolator.Restore(cleanupCtx)
+			if e != nil {
+				err = fmt.Errorf("%w. %v. %w", ErrTesterIsolation, e, err)
+			}
+		}()
+	}
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	// - timeout: the errors of a case whose deadline was exceeded are reported as a timeout
+	defer func() {
+		if err != nil && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			err = fmt.Errorf("%w - %s. %w", ErrTesterTimeout, timeout, err)
+		}
+	}()
 	// - database: tear down
+	//   > not canceled with the case, so that it runs once the case timed out, but bounded by its own timeout
 	defer func() {
-		e := t.dbExecuter.Exec(c.Database.TearDown...)
+		cleanupCtx, cancel := cleanupContext(ctx, timeout)
+		defer cancel()
+		e := t.dbExecuter.Exec(cleanupCtx, c.Database.TearDown...)
 		if e != nil {
+			withCase(e, c.Name)
 			// case of multiple errors:
 			// - wrap errors in a slice of errors
 			// 	 > from `{msg string;err error}`
@@ -47,30 +122,230 @@ func (t *CaseTesterDefault) Test(c *cases.Case) (err error) {
 			//                regardless of the order of the errors, both are unrelated, so is not wrong to identify the first error or the last error
 			//                the full message of the error is still intact
 			//     > advantages: we can use errors.As to get more details about the inner multiple unrelated errors. More programmatic control.
-			err = fmt.Errorf("%w. %v. %w", ErrTesterDatabase, e, err)
+			err = fmt.Errorf("%w. %w. %w", ErrTesterDatabase, e, err)
 		}
 	}()
 	// - database: set up
-	err = t.dbExecuter.Exec(c.Database.SetUp...)
+	err = t.setUp(ctx, c)
+	if err != nil {
+		withCase(err, c.Name)
+		err = fmt.Errorf("%w. %w", ErrTesterDatabase, err)
+		return
+	}
+
+	// scenario
+	if len(c.Scenario.Steps) > 0 {
+		r, err = t.testScenario(ctx, c)
+		return
+	}
+
+	r, err = t.test(ctx, c)
+	return
+}
+
+// setUp sets up the database of a test case: sql files first, then fixtures and set up queries.
+func (t *CaseTesterDefault) setUp(ctx context.Context, c *cases.Case) (err error) {
+	// - sql files: split by the rules of the dialect of the database, if known
+	var dialect cases.Dialect
+	if d, ok := t.dbExecuter.(cases.DbDialect); ok {
+		dialect = d.Dialect()
+	}
+	for _, f := range c.Database.SQLFiles {
+		var stmts []string
+		stmts, err = t.fixtures.Statements(c.Resolve(f), dialect)
+		if err != nil {
+			return
+		}
+		err = t.dbExecuter.Exec(ctx, stmts...)
+		if err != nil {
+			return
+		}
+	}
+	// - fixtures
+	for _, f := range c.Database.Fixtures {
+		var fx []cases.Fixture
+		fx, err = t.fixtures.Fixtures(c.Resolve(f))
+		if err != nil {
+			return
+		}
+		for _, fixture := range fx {
+			err = t.dbExecuter.Insert(ctx, fixture.Table, fixture.Rows)
+			if err != nil {
+				return
+			}
+		}
+	}
+	// - queries
+	err = t.dbExecuter.Exec(ctx, c.Database.SetUp...)
+	return
+}
+
+// testScenario runs the steps of a scenario in order and aggregates their results.
+// - steps are interpolated right before they run, so they can reference the values captured by the previous steps
+// - the scenario stops at the first step that does not pass, as the following ones depend on it
+// - once every step passed, the database expectations of the case are asserted and its captures are taken
+//   from the response of the last step
+func (t *CaseTesterDefault) testScenario(ctx context.Context, c *cases.Case) (r cases.Result, err error) {
+	r = cases.Result{Name: c.Name, Status: cases.StatusPassed}
+	var last *cases.ReceivedResponse
+	for i, s := range c.Scenario.Steps {
+		step := cases.Case{Name: s.Name, File: c.File, Request: s.Request, Response: s.Response, ExpectDatabase: s.ExpectDatabase, Capture: s.Capture}
+		if step.Name == "" {
+			step.Name = fmt.Sprintf("step %d", i+1)
+		}
+
+		// run
+		start := time.Now()
+		var sr cases.Result
+		var is cases.Case
+		is, err = t.variables.Interpolate(&step)
+		if err != nil {
+			err = fmt.Errorf("%w. %v", ErrTesterVariables, err)
+		} else {
+			sr, err = t.test(ctx, &is)
+		}
+		sr.Name = step.Name
+		sr.Started = start
+		sr.Duration = time.Since(start)
+		if err != nil {
+			sr.Status = cases.StatusErrored
+			sr.Err = err
+		}
+
+		// aggregate
+		r.Steps = append(r.Steps, sr)
+		for name, value := range sr.Captured {
+			if r.Captured == nil {
+				r.Captured = make(map[string]any)
+			}
+			r.Captured[name] = value
+		}
+		if err != nil {
+			r.Status = cases.StatusErrored
+			err = fmt.Errorf("%w - %s: %w", ErrTesterScenario, step.Name, err)
+			return
+		}
+		if sr.Status != cases.StatusPassed {
+			r.Status = sr.Status
+			return
+		}
+		last = sr.Response
+	}
+
+	// case
+	ic, err := t.variables.Interpolate(&cases.Case{Name: c.Name, ExpectDatabase: c.ExpectDatabase})
+	if err != nil {
+		r.Status = cases.StatusErrored
+		err = fmt.Errorf("%w. %v", ErrTesterVariables, err)
+		return
+	}
+	err = t.assertDatabase(ctx, &ic, &r)
 	if err != nil {
-		err = fmt.Errorf("%w. %v", ErrTesterDatabase, err)
+		r.Status = cases.StatusErrored
 		return
 	}
+	t.capture(c, last, &r)
+
+	return
+}
 
+// test runs the request of a test case and asserts its response.
+func (t *CaseTesterDefault) test(ctx context.Context, c *cases.Case) (r cases.Result, err error) {
 	// act
 	var resp *http.Response
-	resp, err = t.requester.Do(c)
+	start := time.Now()
+	resp, err = t.requester.Do(ctx, c)
 	if err != nil {
 		err = fmt.Errorf("%w. %v", ErrTesterRequest, err)
 		return
 	}
+	elapsed := time.Since(start)
 
 	// assert
-	err = t.reporter.Report(c, resp)
+	r, err = t.reporter.Report(c, resp)
 	if err != nil {
 		err = fmt.Errorf("%w. %v", ErrTesterReporter, err)
 		return
 	}
+	if r.Response != nil {
+		r.Response.Elapsed = elapsed
+	}
+
+	// assert: database
+	err = t.assertDatabase(ctx, c, &r)
+	if err != nil {
+		return
+	}
+
+	// capture
+	t.capture(c, r.Response, &r)
 
 	return
 }
+
+// assertDatabase runs the database expectations of a test case, adding their verdicts to its result.
+// - after the request and before the tear-down
+func (t *CaseTesterDefault) assertDatabase(ctx context.Context, c *cases.Case, r *cases.Result) (err error) {
+	for _, ex := range c.ExpectDatabase {
+		var rows []map[string]any
+		rows, err = t.dbExecuter.Query(ctx, ex.Query)
+		if err != nil {
+			withCase(err, c.Name)
+			err = fmt.Errorf("%w. %w", ErrTesterDatabase, err)
+			return
+		}
+		var v cases.Verdict
+		v, err = ex.Verdict(rows)
+		if err != nil {
+			err = fmt.Errorf("%w. %v", ErrTesterReporter, err)
+			return
+		}
+		r.AddVerdicts(v)
+	}
+	return
+}
+
+// capture captures the values of the response of a test case into variables, adding them to its result.
+// - only from passed cases, so a failure is not hidden behind a missing value
+// - a failed capture is added as a verdict, keeping the request and response of the result
+func (t *CaseTesterDefault) capture(c *cases.Case, resp *cases.ReceivedResponse, r *cases.Result) {
+	if r.Status != cases.StatusPassed || (len(c.Capture.Body) == 0 && len(c.Capture.Header) == 0) {
+		return
+	}
+
+	var body any
+	var header http.Header
+	if resp != nil {
+		body, header = resp.Body, resp.Header
+	}
+	captured, err := t.variables.Capture(c.Capture, body, header)
+	for name, value := range captured {
+		if r.Captured == nil {
+			r.Captured = make(map[string]any)
+		}
+		r.Captured[name] = value
+	}
+	if err != nil {
+		r.AddVerdicts(cases.Verdict{Field: "capture", Valid: false, Expected: c.Capture, Actual: err.Error()})
+	}
+}
+
+// withCase sets the name of the test case of a query error, if err is one.
+func withCase(err error, name string) {
+	var qe *cases.QueryError
+	if errors.As(err, &qe) {
+		qe.Case = name
+	}
+}
+
+// defaultCleanupTimeout is the timeout of the tear down and restore of the database of a case without timeout.
+const defaultCleanupTimeout = 30 * time.Second
+
+// cleanupContext returns the context to clean up a case: it is not canceled with the case, so that
+// it runs once the case timed out or was interrupted, but it is bounded by its own timeout.
+func cleanupContext(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout <= 0 {
+		timeout = defaultCleanupTimeout
+	}
+	return context.WithTimeout(context.WithoutCancel(ctx), timeout)
+}
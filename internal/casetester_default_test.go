@@ -1,14 +1,23 @@
 package internal_test
 
 import (
+	"context"
+	"database/sql"
 	"errors"
+	"io"
 	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/LNMMusic/tester/internal"
 	"github.com/LNMMusic/tester/internal/cases"
 
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	_ "modernc.org/sqlite"
 )
 
 // Tests for CaseTester Test method
@@ -17,11 +26,11 @@ func TestCaseTesterDefault_Test(t *testing.T) {
 		// arrange
 		// - dbexecuter
 		db := cases.NewDbExecuterMock()
-		db.On("Exec", []string{"query 1", "query 2"}).Return(nil)
-		db.On("Exec", []string{"query 3", "query 4"}).Return(nil)
+		db.On("Exec", mock.Anything, []string{"query 1", "query 2"}).Return(nil)
+		db.On("Exec", mock.Anything, []string{"query 3", "query 4"}).Return(nil)
 		// - requester
 		rq := cases.NewRequesterMock()
-		rq.On("Do", &cases.Case{
+		rq.On("Do", mock.Anything, &cases.Case{
 			Database: cases.Database{
 				SetUp: []string{"query 1", "query 2"},
 				TearDown: []string{"query 3", "query 4"},
@@ -34,12 +43,12 @@ func TestCaseTesterDefault_Test(t *testing.T) {
 				SetUp: []string{"query 1", "query 2"},
 				TearDown: []string{"query 3", "query 4"},
 			},
-		}, &http.Response{}).Return(nil)
+		}, &http.Response{}).Return(cases.Result{Status: cases.StatusPassed}, nil)
 		// - tester
-		ts := internal.NewCaseTesterDefault(db, rq, rp)
+		ts := internal.NewCaseTesterDefault(db, rq, rp, nil, 0)
 
 		// act
-		err := ts.Test(&cases.Case{
+		r, err := ts.Test(context.Background(), &cases.Case{
 			Database: cases.Database{
 				SetUp: []string{"query 1", "query 2"},
 				TearDown: []string{"query 3", "query 4"},
@@ -48,6 +57,7 @@ func TestCaseTesterDefault_Test(t *testing.T) {
 
 		// assert
 		require.NoError(t, err)
+		require.Equal(t, cases.Result{Status: cases.StatusPassed}, r)
 		db.AssertExpectations(t)
 		rq.AssertExpectations(t)
 		rp.AssertExpectations(t)
@@ -57,17 +67,17 @@ func TestCaseTesterDefault_Test(t *testing.T) {
 		// arrange
 		// - dbexecuter
 		db := cases.NewDbExecuterMock()
-		db.On("Exec", []string{"query 1", "query 2"}).Return(errors.New("dbexecuter: internal error"))
-		db.On("Exec", []string{"query 3", "query 4"}).Return(nil)
+		db.On("Exec", mock.Anything, []string{"query 1", "query 2"}).Return(errors.New("dbexecuter: internal error"))
+		db.On("Exec", mock.Anything, []string{"query 3", "query 4"}).Return(nil)
 		// - requester
 		// ...
 		// - reporter
 		// ...
 		// - tester
-		ts := internal.NewCaseTesterDefault(db, nil, nil)
+		ts := internal.NewCaseTesterDefault(db, nil, nil, nil, 0)
 
 		// act
-		err := ts.Test(&cases.Case{
+		_, err := ts.Test(context.Background(), &cases.Case{
 			Database: cases.Database{
 				SetUp: []string{"query 1", "query 2"},
 				TearDown: []string{"query 3", "query 4"},
@@ -84,11 +94,11 @@ func TestCaseTesterDefault_Test(t *testing.T) {
 		// arrange
 		// - dbexecuter
 		db := cases.NewDbExecuterMock()
-		db.On("Exec", []string{"query 1", "query 2"}).Return(nil)
-		db.On("Exec", []string{"query 3", "query 4"}).Return(errors.New("dbexecuter: internal error"))
+		db.On("Exec", mock.Anything, []string{"query 1", "query 2"}).Return(nil)
+		db.On("Exec", mock.Anything, []string{"query 3", "query 4"}).Return(errors.New("dbexecuter: internal error"))
 		// - requester
 		rq := cases.NewRequesterMock()
-		rq.On("Do", &cases.Case{
+		rq.On("Do", mock.Anything, &cases.Case{
 			Database: cases.Database{
 				SetUp: []string{"query 1", "query 2"},
 				TearDown: []string{"query 3", "query 4"},
@@ -101,12 +111,12 @@ func TestCaseTesterDefault_Test(t *testing.T) {
 				SetUp: []string{"query 1", "query 2"},
 				TearDown: []string{"query 3", "query 4"},
 			},
-		}, &http.Response{}).Return(nil)
+		}, &http.Response{}).Return(cases.Result{}, nil)
 		// - tester
-		ts := internal.NewCaseTesterDefault(db, rq, rp)
+		ts := internal.NewCaseTesterDefault(db, rq, rp, nil, 0)
 			
 		// act
-		err := ts.Test(&cases.Case{
+		_, err := ts.Test(context.Background(), &cases.Case{
 			Database: cases.Database{
 				SetUp: []string{"query 1", "query 2"},
 				TearDown: []string{"query 3", "query 4"},
@@ -125,11 +135,11 @@ func TestCaseTesterDefault_Test(t *testing.T) {
 		// arrange
 		// - dbexecuter
 		db := cases.NewDbExecuterMock()
-		db.On("Exec", []string{"query 1", "query 2"}).Return(nil)
-		db.On("Exec", []string{"query 3", "query 4"}).Return(nil)
+		db.On("Exec", mock.Anything, []string{"query 1", "query 2"}).Return(nil)
+		db.On("Exec", mock.Anything, []string{"query 3", "query 4"}).Return(nil)
 		// - requester
 		rq := cases.NewRequesterMock()
-		rq.On("Do", &cases.Case{
+		rq.On("Do", mock.Anything, &cases.Case{
 			Database: cases.Database{
 				SetUp: []string{"query 1", "query 2"},
 				TearDown: []string{"query 3", "query 4"},
@@ -138,10 +148,10 @@ func TestCaseTesterDefault_Test(t *testing.T) {
 		// - reporter
 		// ...
 		// - tester
-		ts := internal.NewCaseTesterDefault(db, rq, nil)
+		ts := internal.NewCaseTesterDefault(db, rq, nil, nil, 0)
 
 		// act
-		err := ts.Test(&cases.Case{
+		_, err := ts.Test(context.Background(), &cases.Case{
 			Database: cases.Database{
 				SetUp: []string{"query 1", "query 2"},
 				TearDown: []string{"query 3", "query 4"},
@@ -159,11 +169,11 @@ func TestCaseTesterDefault_Test(t *testing.T) {
 		// arrange
 		// - dbexecuter
 		db := cases.NewDbExecuterMock()
-		db.On("Exec", []string{"query 1", "query 2"}).Return(nil)
-		db.On("Exec", []string{"query 3", "query 4"}).Return(nil)
+		db.On("Exec", mock.Anything, []string{"query 1", "query 2"}).Return(nil)
+		db.On("Exec", mock.Anything, []string{"query 3", "query 4"}).Return(nil)
 		// - requester
 		rq := cases.NewRequesterMock()
-		rq.On("Do", &cases.Case{
+		rq.On("Do", mock.Anything, &cases.Case{
 			Database: cases.Database{
 				SetUp: []string{"query 1", "query 2"},
 				TearDown: []string{"query 3", "query 4"},
@@ -176,12 +186,12 @@ func TestCaseTesterDefault_Test(t *testing.T) {
 				SetUp: []string{"query 1", "query 2"},
 				TearDown: []string{"query 3", "query 4"},
 			},
-		}, &http.Response{}).Return(errors.New("reporter: internal error"))
+		}, &http.Response{}).Return(cases.Result{}, errors.New("reporter: internal error"))
 		// - tester
-		ts := internal.NewCaseTesterDefault(db, rq, rp)
+		ts := internal.NewCaseTesterDefault(db, rq, rp, nil, 0)
 
 		// act
-		err := ts.Test(&cases.Case{
+		_, err := ts.Test(context.Background(), &cases.Case{
 			Database: cases.Database{
 				SetUp: []string{"query 1", "query 2"},
 				TearDown: []string{"query 3", "query 4"},
@@ -200,11 +210,11 @@ func TestCaseTesterDefault_Test(t *testing.T) {
 		// arrange
 		// - dbexecuter
 		db := cases.NewDbExecuterMock()
-		db.On("Exec", []string{"query 1", "query 2"}).Return(nil)
-		db.On("Exec", []string{"query 3", "query 4"}).Return(errors.New("dbexecuter: internal error"))
+		db.On("Exec", mock.Anything, []string{"query 1", "query 2"}).Return(nil)
+		db.On("Exec", mock.Anything, []string{"query 3", "query 4"}).Return(errors.New("dbexecuter: internal error"))
 		// - requester
 		rq := cases.NewRequesterMock()
-		rq.On("Do", &cases.Case{
+		rq.On("Do", mock.Anything, &cases.Case{
 			Database: cases.Database{
 				SetUp: []string{"query 1", "query 2"},
 				TearDown: []string{"query 3", "query 4"},
@@ -217,12 +227,12 @@ func TestCaseTesterDefault_Test(t *testing.T) {
 				SetUp: []string{"query 1", "query 2"},
 				TearDown: []string{"query 3", "query 4"},
 			},
-		}, &http.Response{}).Return(errors.New("reporter: internal error"))
+		}, &http.Response{}).Return(cases.Result{}, errors.New("reporter: internal error"))
 		// - tester
-		ts := internal.NewCaseTesterDefault(db, rq, rp)
+		ts := internal.NewCaseTesterDefault(db, rq, rp, nil, 0)
 
 		// act
-		err := ts.Test(&cases.Case{
+		_, err := ts.Test(context.Background(), &cases.Case{
 			Database: cases.Database{
 				SetUp: []string{"query 1", "query 2"},
 				TearDown: []string{"query 3", "query 4"},
@@ -237,4 +247,677 @@ func TestCaseTesterDefault_Test(t *testing.T) {
 		rq.AssertExpectations(t)
 		rp.AssertExpectations(t)
 	})
-}
\ No newline at end of file
+
+	t.Run("case 7: success to test - capture and interpolate variables", func(t *testing.T) {
+		// arrange
+		// - dbexecuter
+		db := cases.NewDbExecuterMock()
+		db.On("Exec", mock.Anything, []string(nil)).Return(nil)
+		// - requester
+		rq := cases.NewRequesterMock()
+		rq.On("Do", mock.Anything, &cases.Case{
+			Name: "create",
+			Capture: cases.Capture{Body: map[string]string{"$.id": "task_id"}},
+		}).Return(&http.Response{}, nil)
+		rq.On("Do", mock.Anything, &cases.Case{
+			Name: "get",
+			Request: cases.Request{Path: "/tasks/7"},
+		}).Return(&http.Response{}, nil)
+		// - reporter
+		rp := cases.NewReporterMock()
+		rp.On("Report", &cases.Case{
+			Name: "create",
+			Capture: cases.Capture{Body: map[string]string{"$.id": "task_id"}},
+		}, &http.Response{}).Return(cases.Result{
+			Status: cases.StatusPassed,
+			Response: &cases.ReceivedResponse{Body: map[string]any{"id": 7.0}},
+		}, nil)
+		rp.On("Report", &cases.Case{
+			Name: "get",
+			Request: cases.Request{Path: "/tasks/7"},
+		}, &http.Response{}).Return(cases.Result{Status: cases.StatusPassed}, nil)
+		// - tester
+		ts := internal.NewCaseTesterDefault(db, rq, rp, nil, 0)
+
+		// act
+		r1, err1 := ts.Test(context.Background(), &cases.Case{
+			Name: "create",
+			Capture: cases.Capture{Body: map[string]string{"$.id": "task_id"}},
+		})
+		r2, err2 := ts.Test(context.Background(), &cases.Case{
+			Name: "get",
+			Request: cases.Request{Path: "/tasks/{{ task_id }}"},
+		})
+
+		// assert
+		require.NoError(t, err1)
+		require.NoError(t, err2)
+		require.Equal(t, map[string]any{"task_id": 7.0}, r1.Captured)
+		require.Equal(t, cases.StatusPassed, r2.Status)
+		db.AssertExpectations(t)
+		rq.AssertExpectations(t)
+		rp.AssertExpectations(t)
+	})
+
+	t.Run("case 8: fail to test - unknown variable", func(t *testing.T) {
+		// arrange
+		db := cases.NewDbExecuterMock()
+		rq := cases.NewRequesterMock()
+		rp := cases.NewReporterMock()
+		ts := internal.NewCaseTesterDefault(db, rq, rp, nil, 0)
+
+		// act
+		_, err := ts.Test(context.Background(), &cases.Case{
+			Request: cases.Request{Path: "/tasks/{{ task_id }}"},
+		})
+
+		// assert
+		require.ErrorIs(t, err, internal.ErrTesterVariables)
+		require.EqualError(t, err, "tester: variables error. unknown variable - task_id")
+		db.AssertExpectations(t)
+		rq.AssertExpectations(t)
+		rp.AssertExpectations(t)
+	})
+
+	t.Run("case 9: success to test - scenario", func(t *testing.T) {
+		// arrange
+		// - dbexecuter: a single set-up and tear-down for every step
+		db := cases.NewDbExecuterMock()
+		db.On("Exec", mock.Anything, []string{"query 1"}).Return(nil).Once()
+		db.On("Exec", mock.Anything, []string{"query 2"}).Return(nil).Once()
+		// - requester
+		rq := cases.NewRequesterMock()
+		rq.On("Do", mock.Anything, &cases.Case{
+			Name: "create",
+			Request: cases.Request{Method: "POST", Path: "/tasks"},
+			Capture: cases.Capture{Body: map[string]string{"$.id": "task_id"}},
+		}).Return(&http.Response{}, nil)
+		rq.On("Do", mock.Anything, &cases.Case{
+			Name: "step 2",
+			Request: cases.Request{Method: "GET", Path: "/tasks/7"},
+		}).Return(&http.Response{}, nil)
+		// - reporter
+		rp := cases.NewReporterMock()
+		rp.On("Report", &cases.Case{
+			Name: "create",
+			Request: cases.Request{Method: "POST", Path: "/tasks"},
+			Capture: cases.Capture{Body: map[string]string{"$.id": "task_id"}},
+		}, &http.Response{}).Return(cases.Result{
+			Status: cases.StatusPassed,
+			Response: &cases.ReceivedResponse{Body: map[string]any{"id": 7.0}},
+		}, nil)
+		rp.On("Report", &cases.Case{
+			Name: "step 2",
+			Request: cases.Request{Method: "GET", Path: "/tasks/7"},
+		}, &http.Response{}).Return(cases.Result{Status: cases.StatusPassed}, nil)
+		// - tester
+		ts := internal.NewCaseTesterDefault(db, rq, rp, nil, 0)
+
+		// act
+		r, err := ts.Test(context.Background(), &cases.Case{
+			Name: "task lifecycle",
+			Database: cases.Database{
+				SetUp: []string{"query 1"},
+				TearDown: []string{"query 2"},
+			},
+			Scenario: cases.Scenario{Steps: []cases.Step{
+				{Name: "create", Request: cases.Request{Method: "POST", Path: "/tasks"}, Capture: cases.Capture{Body: map[string]string{"$.id": "task_id"}}},
+				{Request: cases.Request{Method: "GET", Path: "/tasks/{{ task_id }}"}},
+			}},
+		})
+
+		// assert
+		require.NoError(t, err)
+		require.Equal(t, cases.StatusPassed, r.Status)
+		require.Equal(t, map[string]any{"task_id": 7.0}, r.Captured)
+		require.Len(t, r.Steps, 2)
+		require.Equal(t, "create", r.Steps[0].Name)
+		require.Equal(t, cases.StatusPassed, r.Steps[0].Status)
+		require.Equal(t, "step 2", r.Steps[1].Name)
+		require.Equal(t, cases.StatusPassed, r.Steps[1].Status)
+		db.AssertExpectations(t)
+		rq.AssertExpectations(t)
+		rp.AssertExpectations(t)
+	})
+
+	t.Run("case 10: fail to test - scenario stops at the first failed step", func(t *testing.T) {
+		// arrange
+		// - dbexecuter
+		db := cases.NewDbExecuterMock()
+		db.On("Exec", mock.Anything, []string(nil)).Return(nil)
+		// - requester
+		rq := cases.NewRequesterMock()
+		rq.On("Do", mock.Anything, &cases.Case{Name: "login"}).Return(&http.Response{}, nil)
+		// - reporter
+		rp := cases.NewReporterMock()
+		rp.On("Report", &cases.Case{Name: "login"}, &http.Response{}).Return(cases.Result{
+			Status: cases.StatusFailed,
+			Verdicts: []cases.Verdict{{Field: "code", Valid: false, Expected: 200, Actual: 401}},
+		}, nil)
+		// - tester
+		ts := internal.NewCaseTesterDefault(db, rq, rp, nil, 0)
+
+		// act
+		r, err := ts.Test(context.Background(), &cases.Case{
+			Name: "task lifecycle",
+			Scenario: cases.Scenario{Steps: []cases.Step{
+				{Name: "login"},
+				{Name: "create"},
+			}},
+		})
+
+		// assert
+		require.NoError(t, err)
+		require.Equal(t, cases.StatusFailed, r.Status)
+		require.Len(t, r.Steps, 1)
+		require.Equal(t, "- step 'login': FAIL\n- expected code: 200\n- actual code: 401\n", r.Failure())
+		db.AssertExpectations(t)
+		rq.AssertExpectations(t)
+		rp.AssertExpectations(t)
+	})
+
+	t.Run("case 11: fail to test - scenario step error", func(t *testing.T) {
+		// arrange
+		// - dbexecuter
+		db := cases.NewDbExecuterMock()
+		db.On("Exec", mock.Anything, []string(nil)).Return(nil)
+		// - requester
+		rq := cases.NewRequesterMock()
+		rq.On("Do", mock.Anything, &cases.Case{Name: "login"}).Return((*http.Response)(nil), errors.New("requester: internal error"))
+		// - reporter
+		// ...
+		// - tester
+		ts := internal.NewCaseTesterDefault(db, rq, nil, nil, 0)
+
+		// act
+		r, err := ts.Test(context.Background(), &cases.Case{
+			Name: "task lifecycle",
+			Scenario: cases.Scenario{Steps: []cases.Step{
+				{Name: "login"},
+			}},
+		})
+
+		// assert
+		require.ErrorIs(t, err, internal.ErrTesterScenario)
+		require.ErrorIs(t, err, internal.ErrTesterRequest)
+		require.EqualError(t, err, "tester: scenario error - login: tester: request error. requester: internal error")
+		require.Len(t, r.Steps, 1)
+		require.Equal(t, cases.StatusErrored, r.Steps[0].Status)
+		db.AssertExpectations(t)
+		rq.AssertExpectations(t)
+	})
+
+	t.Run("case 12: success to test - isolation", func(t *testing.T) {
+		// arrange
+		// - dbexecuter
+		db := cases.NewDbExecuterMock()
+		db.On("Exec", mock.Anything, []string{"query 1"}).Return(nil)
+		db.On("Exec", mock.Anything, []string(nil)).Return(nil)
+		// - requester
+		rq := cases.NewRequesterMock()
+		rq.On("Do", mock.Anything, &cases.Case{
+			Database: cases.Database{SetUp: []string{"query 1"}},
+		}).Return(&http.Response{}, nil)
+		// - reporter
+		rp := cases.NewReporterMock()
+		rp.On("Report", &cases.Case{
+			Database: cases.Database{SetUp: []string{"query 1"}},
+		}, &http.Response{}).Return(cases.Result{Status: cases.StatusPassed}, nil)
+		// - isolator
+		is := cases.NewIsolatorMock()
+		is.On("Save", mock.Anything).Return(nil).Once()
+		is.On("Restore", mock.Anything).Return(nil).Once()
+		// - tester
+		ts := internal.NewCaseTesterDefault(db, rq, rp, is, 0)
+
+		// act
+		r, err := ts.Test(context.Background(), &cases.Case{
+			Database: cases.Database{SetUp: []string{"query 1"}},
+		})
+
+		// assert
+		require.NoError(t, err)
+		require.Equal(t, cases.StatusPassed, r.Status)
+		db.AssertExpectations(t)
+		rq.AssertExpectations(t)
+		rp.AssertExpectations(t)
+		is.AssertExpectations(t)
+	})
+
+	t.Run("case 13: fail to test - isolation save", func(t *testing.T) {
+		// arrange
+		// - isolator
+		is := cases.NewIsolatorMock()
+		is.On("Save", mock.Anything).Return(errors.New("isolator: internal error"))
+		// - tester
+		ts := internal.NewCaseTesterDefault(nil, nil, nil, is, 0)
+
+		// act
+		_, err := ts.Test(context.Background(), &cases.Case{})
+
+		// assert
+		require.ErrorIs(t, err, internal.ErrTesterIsolation)
+		require.EqualError(t, err, "tester: isolation error. isolator: internal error")
+		is.AssertExpectations(t)
+	})
+
+	t.Run("case 14: success to test - database expectations", func(t *testing.T) {
+		// arrange
+		// - dbexecuter
+		db := cases.NewDbExecuterMock()
+		db.On("Exec", mock.Anything, []string(nil)).Return(nil)
+		db.On("Query", mock.Anything, "SELECT title FROM tasks").Return([]map[string]any{{"title": "task 2"}}, nil)
+		// - requester
+		rq := cases.NewRequesterMock()
+		rq.On("Do", mock.Anything, mock.Anything).Return(&http.Response{}, nil)
+		// - reporter
+		rp := cases.NewReporterMock()
+		rp.On("Report", mock.Anything, &http.Response{}).Return(cases.Result{
+			Status: cases.StatusPassed,
+			Verdicts: []cases.Verdict{{Field: "code", Valid: true, Expected: 201, Actual: 201}},
+		}, nil)
+		// - tester
+		ts := internal.NewCaseTesterDefault(db, rq, rp, nil, 0)
+
+		// act
+		r, err := ts.Test(context.Background(), &cases.Case{
+			ExpectDatabase: []cases.DatabaseExpectation{
+				{Query: "SELECT title FROM tasks", Rows: []map[string]any{{"title": "task 1"}}},
+			},
+		})
+
+		// assert
+		require.NoError(t, err)
+		require.Equal(t, cases.StatusFailed, r.Status)
+		require.Len(t, r.Verdicts, 2)
+		require.Equal(t, "- database (SELECT title FROM tasks) diff (- expected, + actual):\n  - /0/title: \"task 1\"\n  + /0/title: \"task 2\"\n", r.Failure())
+		db.AssertExpectations(t)
+		rq.AssertExpectations(t)
+		rp.AssertExpectations(t)
+	})
+
+	t.Run("case 15: fail to test - query error of the database set-up", func(t *testing.T) {
+		// arrange
+		// - dbexecuter
+		qe := &cases.QueryError{Index: 1, Query: "query 2", Err: errors.New("duplicate key")}
+		db := cases.NewDbExecuterMock()
+		db.On("Exec", mock.Anything, []string{"query 1", "query 2"}).Return(qe)
+		db.On("Exec", mock.Anything, []string(nil)).Return(nil)
+		// - tester
+		ts := internal.NewCaseTesterDefault(db, nil, nil, nil, 0)
+
+		// act
+		_, err := ts.Test(context.Background(), &cases.Case{
+			Name: "create task",
+			Database: cases.Database{SetUp: []string{"query 1", "query 2"}},
+		})
+
+		// assert
+		var e *cases.QueryError
+		require.ErrorIs(t, err, internal.ErrTesterDatabase)
+		require.ErrorAs(t, err, &e)
+		require.Equal(t, "create task", e.Case)
+		require.EqualError(t, err, "tester: database error. error executing query 1 of case 'create task': query 2 - duplicate key")
+		db.AssertExpectations(t)
+	})
+
+	t.Run("case 16: fail to test - validation without a database", func(t *testing.T) {
+		// arrange
+		// - tester
+		ts := internal.NewCaseTesterDefault(cases.NewDbExecuterNone(), nil, nil, nil, 0)
+
+		// act
+		_, err := ts.Test(context.Background(), &cases.Case{
+			Database: cases.Database{SetUp: []string{"query 1"}},
+		})
+
+		// assert
+		require.ErrorIs(t, err, internal.ErrTesterValidation)
+		require.EqualError(t, err, "tester: validation error. no database configured - the case declares set_up")
+	})
+
+	t.Run("case 17: success to test - sql files and fixtures", func(t *testing.T) {
+		// arrange
+		// - files, relative to the file of the case
+		dir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "schema.sql"), []byte("DELETE FROM tasks; DELETE FROM users;"), 0o644))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "tasks.csv"), []byte("id,title\n1,task 1\n"), 0o644))
+		// - dbexecuter
+		db := cases.NewDbExecuterMock()
+		db.On("Exec", mock.Anything, []string{"DELETE FROM tasks", "DELETE FROM users"}).Return(nil).Once()
+		db.On("Insert", mock.Anything, "tasks", []map[string]any{{"id": "1", "title": "task 1"}}).Return(nil).Once()
+		db.On("Exec", mock.Anything, []string{"query 1"}).Return(nil).Once()
+		db.On("Exec", mock.Anything, []string(nil)).Return(nil).Once()
+		// - requester
+		rq := cases.NewRequesterMock()
+		rq.On("Do", mock.Anything, mock.Anything).Return(&http.Response{}, nil)
+		// - reporter
+		rp := cases.NewReporterMock()
+		rp.On("Report", mock.Anything, &http.Response{}).Return(cases.Result{Status: cases.StatusPassed}, nil)
+		// - tester
+		ts := internal.NewCaseTesterDefault(db, rq, rp, nil, 0)
+
+		// act
+		r, err := ts.Test(context.Background(), &cases.Case{
+			File: filepath.Join(dir, "cases.json"),
+			Database: cases.Database{
+				SQLFiles: []string{"schema.sql"},
+				Fixtures: []string{"tasks.csv"},
+				SetUp: []string{"query 1"},
+			},
+		})
+
+		// assert
+		require.NoError(t, err)
+		require.Equal(t, cases.StatusPassed, r.Status)
+		db.AssertExpectations(t)
+		rq.AssertExpectations(t)
+		rp.AssertExpectations(t)
+	})
+
+	t.Run("case 18: fail to test - timeout of the case, tear-down still runs", func(t *testing.T) {
+		// arrange
+		// - dbexecuter: tear-down with a context that is not canceled
+		db := cases.NewDbExecuterMock()
+		db.On("Exec", mock.Anything, []string(nil)).Return(nil).Once()
+		db.On("Exec", mock.MatchedBy(func(ctx context.Context) bool { return ctx.Err() == nil }), []string{"query 1"}).Return(nil).Once()
+		// - requester: hangs until the case is canceled
+		rq := cases.NewRequesterMock()
+		rq.On("Do", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			<-args.Get(0).(context.Context).Done()
+		}).Return((*http.Response)(nil), context.DeadlineExceeded)
+		// - tester: default timeout overridden by the case
+		ts := internal.NewCaseTesterDefault(db, rq, nil, nil, time.Hour)
+
+		// act
+		_, err := ts.Test(context.Background(), &cases.Case{
+			Database: cases.Database{TearDown: []string{"query 1"}},
+			Timeout:  cases.Duration(10 * time.Millisecond),
+		})
+
+		// assert
+		require.ErrorIs(t, err, internal.ErrTesterTimeout)
+		require.ErrorIs(t, err, internal.ErrTesterRequest)
+		require.EqualError(t, err, "tester: timeout error - 10ms. tester: request error. context deadline exceeded")
+		db.AssertExpectations(t)
+		rq.AssertExpectations(t)
+	})
+
+	t.Run("case 19: fail to test - canceled case, tear-down still runs", func(t *testing.T) {
+		// arrange
+		ctx, cancel := context.WithCancel(context.Background())
+		// - dbexecuter: tear-down with a context that is not canceled
+		db := cases.NewDbExecuterMock()
+		db.On("Exec", mock.Anything, []string(nil)).Return(nil).Once()
+		db.On("Exec", mock.MatchedBy(func(ctx context.Context) bool { return ctx.Err() == nil }), []string{"query 1"}).Return(nil).Once()
+		// - requester: canceled while in flight
+		rq := cases.NewRequesterMock()
+		rq.On("Do", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			cancel()
+		}).Return((*http.Response)(nil), context.Canceled)
+		// - tester
+		ts := internal.NewCaseTesterDefault(db, rq, nil, nil, 0)
+
+		// act
+		_, err := ts.Test(ctx, &cases.Case{
+			Database: cases.Database{TearDown: []string{"query 1"}},
+		})
+
+		// assert
+		require.ErrorIs(t, err, internal.ErrTesterRequest)
+		require.NotErrorIs(t, err, internal.ErrTesterTimeout)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("case 20: fail to test - failed capture keeps the request and response", func(t *testing.T) {
+		// arrange
+		// - dbexecuter
+		db := cases.NewDbExecuterMock()
+		db.On("Exec", mock.Anything, []string(nil)).Return(nil)
+		// - requester
+		rq := cases.NewRequesterMock()
+		rq.On("Do", mock.Anything, mock.Anything).Return(&http.Response{}, nil)
+		// - reporter
+		sent := &cases.SentRequest{Method: http.MethodPost, URL: "/tasks"}
+		received := &cases.ReceivedResponse{Code: 201, Body: map[string]any{"title": "task 1"}}
+		rp := cases.NewReporterMock()
+		rp.On("Report", mock.Anything, &http.Response{}).Return(cases.Result{
+			Status:   cases.StatusPassed,
+			Request:  sent,
+			Response: received,
+			Verdicts: []cases.Verdict{{Field: "code", Valid: true, Expected: 201, Actual: 201}},
+		}, nil)
+		// - tester
+		ts := internal.NewCaseTesterDefault(db, rq, rp, nil, 0)
+
+		// act
+		r, err := ts.Test(context.Background(), &cases.Case{
+			Name:    "create",
+			Capture: cases.Capture{Body: map[string]string{"$.id": "task_id"}},
+		})
+
+		// assert
+		require.NoError(t, err)
+		require.Equal(t, cases.StatusFailed, r.Status)
+		require.Same(t, sent, r.Request)
+		require.Same(t, received, r.Response)
+		require.Len(t, r.Verdicts, 2)
+		require.Equal(t, "capture", r.Verdicts[1].Field)
+		require.False(t, r.Verdicts[1].Valid)
+	})
+
+	t.Run("case 21: success to test - scenario step with a file relative to the case", func(t *testing.T) {
+		// arrange
+		// - files, relative to the file of the case
+		dir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "payload.bin"), []byte("payload"), 0o644))
+		// - server: records the body of the request
+		var body []byte
+		sv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, _ = io.ReadAll(r.Body)
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer sv.Close()
+		// - dbexecuter
+		db := cases.NewDbExecuterMock()
+		db.On("Exec", mock.Anything, []string(nil)).Return(nil)
+		// - reporter
+		rp := cases.NewReporterMock()
+		rp.On("Report", mock.Anything, mock.Anything).Return(cases.Result{Status: cases.StatusPassed}, nil)
+		// - tester
+		ts := internal.NewCaseTesterDefault(db, cases.NewRequesterDefault(sv.URL, nil), rp, nil, 0)
+
+		// act
+		r, err := ts.Test(context.Background(), &cases.Case{
+			Name: "upload",
+			File: filepath.Join(dir, "cases.json"),
+			Scenario: cases.Scenario{Steps: []cases.Step{
+				{Request: cases.Request{Method: http.MethodPut, Path: "/", BodyType: cases.BodyBinary, Body: map[string]any{"file": "payload.bin"}}},
+			}},
+		})
+
+		// assert
+		require.NoError(t, err)
+		require.Equal(t, cases.StatusPassed, r.Status)
+		require.Equal(t, "payload", string(body))
+	})
+
+	t.Run("case 22: success to test - the wait for the isolation of the database does not count against the timeout of the case", func(t *testing.T) {
+		// arrange
+		// - isolator: locked by another case, released after the timeout of the case
+		sqlDB, err := sql.Open("sqlite", ":memory:")
+		require.NoError(t, err)
+		defer sqlDB.Close()
+		is := cases.NewIsolatorTruncate(sqlDB, cases.DialectSQLite, nil)
+		require.NoError(t, is.Save(context.Background()))
+		time.AfterFunc(30*time.Millisecond, func() { _ = is.Restore(context.Background()) })
+		// - dbexecuter
+		db := cases.NewDbExecuterMock()
+		db.On("Exec", mock.Anything, []string(nil)).Return(nil)
+		// - requester
+		rq := cases.NewRequesterMock()
+		rq.On("Do", mock.Anything, mock.Anything).Return(&http.Response{}, nil)
+		// - reporter
+		rp := cases.NewReporterMock()
+		rp.On("Report", mock.Anything, &http.Response{}).Return(cases.Result{Status: cases.StatusPassed}, nil)
+		// - tester
+		ts := internal.NewCaseTesterDefault(db, rq, rp, is, 20*time.Millisecond)
+
+		// act
+		r, err := ts.Test(context.Background(), &cases.Case{})
+
+		// assert
+		require.NoError(t, err)
+		require.Equal(t, cases.StatusPassed, r.Status)
+	})
+
+	t.Run("case 23: fail to test - canceled case waiting for the isolation of the database", func(t *testing.T) {
+		// arrange
+		// - isolator: locked by another case
+		db, err := sql.Open("sqlite", ":memory:")
+		require.NoError(t, err)
+		defer db.Close()
+		is := cases.NewIsolatorTruncate(db, cases.DialectSQLite, nil)
+		require.NoError(t, is.Save(context.Background()))
+		// - tester
+		ts := internal.NewCaseTesterDefault(nil, nil, nil, is, 0)
+		ctx, cancel := context.WithCancel(context.Background())
+		time.AfterFunc(10*time.Millisecond, cancel)
+
+		// act
+		_, err = ts.Test(ctx, &cases.Case{})
+
+		// assert
+		require.ErrorIs(t, err, internal.ErrTesterIsolation)
+		require.EqualError(t, err, "tester: isolation error. isolation error - waiting for the database: context canceled")
+	})
+
+	t.Run("case 24: fail to test - capture of a result without a response", func(t *testing.T) {
+		// arrange
+		// - dbexecuter
+		db := cases.NewDbExecuterMock()
+		db.On("Exec", mock.Anything, []string(nil)).Return(nil)
+		// - requester
+		rq := cases.NewRequesterMock()
+		rq.On("Do", mock.Anything, mock.Anything).Return(&http.Response{}, nil)
+		// - reporter: no response recorded
+		rp := cases.NewReporterMock()
+		rp.On("Report", mock.Anything, &http.Response{}).Return(cases.Result{Status: cases.StatusPassed}, nil)
+		// - tester
+		ts := internal.NewCaseTesterDefault(db, rq, rp, nil, 0)
+
+		// act
+		r, err := ts.Test(context.Background(), &cases.Case{
+			Name:    "create",
+			Capture: cases.Capture{Header: map[string]string{"Location": "task_url"}},
+		})
+
+		// assert
+		require.NoError(t, err)
+		require.Equal(t, cases.StatusFailed, r.Status)
+		require.Equal(t, "capture", r.Verdicts[0].Field)
+		require.Equal(t, "header not found - Location", r.Verdicts[0].Actual)
+	})
+
+	t.Run("case 25: success to test - scenario with database expectations and captures of the case", func(t *testing.T) {
+		// arrange
+		// - dbexecuter: the expectation of the case references a value captured by a step
+		db := cases.NewDbExecuterMock()
+		db.On("Exec", mock.Anything, []string(nil)).Return(nil)
+		db.On("Query", mock.Anything, "SELECT title FROM tasks WHERE id = 7").Return([]map[string]any{{"title": "task 1"}}, nil).Once()
+		// - requester
+		rq := cases.NewRequesterMock()
+		rq.On("Do", mock.Anything, mock.Anything).Return(&http.Response{}, nil)
+		// - reporter
+		rp := cases.NewReporterMock()
+		rp.On("Report", mock.Anything, &http.Response{}).Return(cases.Result{
+			Status:   cases.StatusPassed,
+			Response: &cases.ReceivedResponse{Code: 201, Body: map[string]any{"id": 7.0}, Header: http.Header{"Location": {"/tasks/7"}}},
+		}, nil)
+		// - tester
+		ts := internal.NewCaseTesterDefault(db, rq, rp, nil, 0)
+
+		// act
+		r, err := ts.Test(context.Background(), &cases.Case{
+			Name: "create",
+			Scenario: cases.Scenario{Steps: []cases.Step{
+				{Name: "create", Capture: cases.Capture{Body: map[string]string{"$.id": "task_id"}}},
+			}},
+			ExpectDatabase: []cases.DatabaseExpectation{
+				{Query: "SELECT title FROM tasks WHERE id = {{ task_id }}", Rows: []map[string]any{{"title": "task 1"}}},
+			},
+			Capture: cases.Capture{Header: map[string]string{"Location": "task_url"}},
+		})
+
+		// assert
+		require.NoError(t, err)
+		require.Equal(t, cases.StatusPassed, r.Status)
+		require.Len(t, r.Verdicts, 1)
+		require.Equal(t, "database (SELECT title FROM tasks WHERE id = 7)", r.Verdicts[0].Field)
+		require.True(t, r.Verdicts[0].Valid)
+		require.Equal(t, map[string]any{"task_id": 7.0, "task_url": "/tasks/7"}, r.Captured)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("case 26: fail to test - scenario with a failed database expectation of the case", func(t *testing.T) {
+		// arrange
+		// - dbexecuter
+		db := cases.NewDbExecuterMock()
+		db.On("Exec", mock.Anything, []string(nil)).Return(nil)
+		db.On("Query", mock.Anything, "SELECT title FROM tasks").Return([]map[string]any{}, nil).Once()
+		// - requester
+		rq := cases.NewRequesterMock()
+		rq.On("Do", mock.Anything, mock.Anything).Return(&http.Response{}, nil)
+		// - reporter
+		rp := cases.NewReporterMock()
+		rp.On("Report", mock.Anything, &http.Response{}).Return(cases.Result{Status: cases.StatusPassed}, nil)
+		// - tester
+		ts := internal.NewCaseTesterDefault(db, rq, rp, nil, 0)
+
+		// act
+		r, err := ts.Test(context.Background(), &cases.Case{
+			Name:     "create",
+			Scenario: cases.Scenario{Steps: []cases.Step{{Name: "create"}}},
+			ExpectDatabase: []cases.DatabaseExpectation{
+				{Query: "SELECT title FROM tasks", Rows: []map[string]any{{"title": "task 1"}}},
+			},
+			Capture: cases.Capture{Header: map[string]string{"Location": "task_url"}},
+		})
+
+		// assert
+		require.NoError(t, err)
+		require.Equal(t, cases.StatusFailed, r.Status)
+		require.Len(t, r.Verdicts, 1)
+		require.False(t, r.Verdicts[0].Valid)
+		require.Nil(t, r.Captured)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("case 27: fail to test - tear down of the database bounded by its own timeout", func(t *testing.T) {
+		// arrange
+		// - dbexecuter: tear down hangs until its context is done
+		db := cases.NewDbExecuterMock()
+		db.On("Exec", mock.Anything, []string(nil)).Return(nil).Once()
+		db.On("Exec", mock.Anything, []string{"query 1"}).Run(func(args mock.Arguments) {
+			<-args.Get(0).(context.Context).Done()
+		}).Return(context.DeadlineExceeded)
+		// - requester
+		rq := cases.NewRequesterMock()
+		rq.On("Do", mock.Anything, mock.Anything).Return(&http.Response{}, nil)
+		// - reporter
+		rp := cases.NewReporterMock()
+		rp.On("Report", mock.Anything, &http.Response{}).Return(cases.Result{Status: cases.StatusPassed}, nil)
+		// - tester
+		ts := internal.NewCaseTesterDefault(db, rq, rp, nil, 10*time.Millisecond)
+
+		// act
+		start := time.Now()
+		_, err := ts.Test(context.Background(), &cases.Case{Database: cases.Database{TearDown: []string{"query 1"}}})
+
+		// assert
+		require.ErrorIs(t, err, internal.ErrTesterDatabase)
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+		require.Less(t, time.Since(start), time.Second)
+		db.AssertExpectations(t)
+	})
+}
@@ -1,6 +1,8 @@
 package internal
 
 import (
+	"context"
+
 	"github.com/LNMMusic/tester/internal/cases"
 	"github.com/stretchr/testify/mock"
 )
@@ -17,10 +19,11 @@ type CaseTesterMock struct {
 }
 
 // Test is a mock of Test.
-func (m *CaseTesterMock) Test(c *cases.Case) (err error) {
-	args := m.Called(c)
+func (m *CaseTesterMock) Test(ctx context.Context, c *cases.Case) (r cases.Result, err error) {
+	args := m.Called(ctx, c)
 
-	err = args.Error(0)
+	r = args.Get(0).(cases.Result)
+	err = args.Error(1)
 
 	return
 }
\ No newline at end of file
@@ -0,0 +1,117 @@
+package internal
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+var (
+	// ErrNotReady is the error returned when a dependency did not become ready in time.
+	ErrNotReady = errors.New("readiness: dependency not ready")
+)
+
+// Dependency is a dependency of the run that must be ready before testing any case.
+type Dependency struct {
+	// Name is the name of the dependency, used to report it.
+	Name string
+	// Ping checks once whether the dependency is ready.
+	Ping func(ctx context.Context) (err error)
+}
+
+// NewDependencyHTTP creates a dependency on an http server, ready once its health endpoint answers with a ready code.
+// - codes is the set of ready codes, any 2xx code by default
+func NewDependencyHTTP(name, url string, client *http.Client, codes ...int) Dependency {
+	if client == nil {
+		client = &http.Client{}
+	}
+
+	return Dependency{
+		Name: name,
+		Ping: func(ctx context.Context) (err error) {
+			req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+			if err != nil {
+				return
+			}
+			resp, err := client.Do(req)
+			if err != nil {
+				return
+			}
+			defer resp.Body.Close()
+			if !readyCode(resp.StatusCode, codes) {
+				err = fmt.Errorf("health check %s answered %d", url, resp.StatusCode)
+				return
+			}
+			return
+		},
+	}
+}
+
+// readyCode returns true if a status code is one of the ready codes, or a 2xx code if there are none.
+func readyCode(code int, codes []int) bool {
+	if len(codes) == 0 {
+		return code >= 200 && code < 300
+	}
+	for _, c := range codes {
+		if c == code {
+			return true
+		}
+	}
+	return false
+}
+
+// NewDependencyDB creates a dependency on a database, ready once it answers a ping.
+func NewDependencyDB(name string, db *sql.DB) Dependency {
+	return Dependency{
+		Name: name,
+		Ping: db.PingContext,
+	}
+}
+
+// WaitReady waits until every dependency is ready, polling each one with exponential backoff.
+// - interval is the first delay between attempts, doubled after each one up to maxBackoff
+// - timeout is shared by every dependency
+// - err names the first dependency that did not become ready, along with its last error
+func WaitReady(ctx context.Context, timeout, interval time.Duration, deps ...Dependency) (err error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	for _, d := range deps {
+		err = waitReady(ctx, interval, d)
+		if err != nil {
+			err = fmt.Errorf("%w - %s after %s: %v", ErrNotReady, d.Name, timeout, err)
+			return
+		}
+	}
+	return
+}
+
+// maxBackoff is the maximum delay between two attempts.
+const maxBackoff = 5 * time.Second
+
+// waitReady waits until a dependency is ready.
+func waitReady(ctx context.Context, interval time.Duration, d Dependency) (err error) {
+	if interval <= 0 {
+		interval = 250 * time.Millisecond
+	}
+
+	for {
+		err = d.Ping(ctx)
+		if err == nil {
+			return
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(interval):
+		}
+		interval *= 2
+		if interval > maxBackoff {
+			interval = maxBackoff
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package internal_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/LNMMusic/tester/internal"
+
+	"github.com/stretchr/testify/require"
+)
+
+// Tests for WaitReady
+func TestWaitReady(t *testing.T) {
+	t.Run("case 1: success - server ready after some attempts", func(t *testing.T) {
+		// arrange
+		// - server: unavailable for the first two attempts
+		var attempts atomic.Int32
+		sv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if attempts.Add(1) <= 2 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer sv.Close()
+		dep := internal.NewDependencyHTTP("server", sv.URL+"/health", nil)
+
+		// act
+		err := internal.WaitReady(context.Background(), time.Second, time.Millisecond, dep)
+
+		// assert
+		require.NoError(t, err)
+		require.Equal(t, int32(3), attempts.Load())
+	})
+
+	t.Run("case 2: error - dependency never ready", func(t *testing.T) {
+		// arrange
+		ready := internal.Dependency{Name: "server", Ping: func(ctx context.Context) error { return nil }}
+		notReady := internal.Dependency{Name: "database", Ping: func(ctx context.Context) error {
+			return errors.New("connection refused")
+		}}
+
+		// act
+		err := internal.WaitReady(context.Background(), 50*time.Millisecond, time.Millisecond, ready, notReady)
+
+		// assert
+		require.ErrorIs(t, err, internal.ErrNotReady)
+		require.EqualError(t, err, "readiness: dependency not ready - database after 50ms: connection refused")
+	})
+
+	t.Run("case 3: error - server answering with a code that is not ready", func(t *testing.T) {
+		tcs := []struct {
+			name  string
+			code  int
+			codes []int
+			err   bool
+		}{
+			{"2xx by default", http.StatusNoContent, nil, false},
+			{"route not mounted yet", http.StatusNotFound, nil, true},
+			{"unauthorized", http.StatusUnauthorized, nil, true},
+			{"configured code", http.StatusUnauthorized, []int{http.StatusOK, http.StatusUnauthorized}, false},
+			{"2xx not configured", http.StatusOK, []int{http.StatusUnauthorized}, true},
+		}
+		for _, tc := range tcs {
+			// arrange
+			sv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tc.code)
+			}))
+			dep := internal.NewDependencyHTTP("server", sv.URL, nil, tc.codes...)
+
+			// act
+			err := dep.Ping(context.Background())
+			sv.Close()
+
+			// assert
+			if tc.err {
+				require.EqualError(t, err, fmt.Sprintf("health check %s answered %d", sv.URL, tc.code), tc.name)
+				continue
+			}
+			require.NoError(t, err, tc.name)
+		}
+	})
+}
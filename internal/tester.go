@@ -1,12 +1,40 @@
 package internal
 
-import "github.com/LNMMusic/tester/internal/cases"
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/LNMMusic/tester/internal/cases"
+)
+
+var (
+	// ErrRunInterrupted is the error of a run whose context was canceled before every case was processed.
+	ErrRunInterrupted = errors.New("tester: run interrupted")
+	// ErrVariableGroup is the error of a case that references a variable captured by a case of another group.
+	ErrVariableGroup = errors.New("tester: variable captured by another group")
+)
+
+// variablesGroup is the implicit group of the cases without a group that capture variables.
+const variablesGroup = "$variables"
 
 // NewTester creates a new tester.
-func NewTester(rd cases.Reader, ct CaseTester) (t *Tester) {
+// - workers is the number of cases tested concurrently (1 by default)
+func NewTester(rd cases.Reader, ct CaseTester, workers int, rp ...cases.ResultReporter) (t *Tester) {
+	// default config
+	defaultWorkers := 1
+	if workers > 0 {
+		defaultWorkers = workers
+	}
+
 	t = &Tester{
-		rd: rd,
-		ct: ct,
+		rd:      rd,
+		ct:      ct,
+		workers: defaultWorkers,
+		rp:      rp,
 	}
 	return
 }
@@ -17,28 +45,281 @@ type Tester struct {
 	rd cases.Reader
 	// ct is the tester of cases.
 	ct CaseTester
+	// workers is the number of cases tested concurrently.
+	workers int
+	// rp is the set of reporters of the results.
+	rp []cases.ResultReporter
+}
+
+// job is a case dispatched to a worker.
+type job struct {
+	// index is the position of the case in the stream.
+	index int
+	// ticket is the turn of the case within its group.
+	ticket int
+	// c is the case to test.
+	c cases.Case
+	// r is the result of the case.
+	r cases.Result
+	// err is the error that prevents the case from running, if any.
+	err error
 }
 
 // Run test a stream of cases.
-func (t *Tester) Run() (err error) {
-	// read cases
-	for {
-		// read case
-		var c cases.Case
-		c, err = t.rd.Read()
-		if err != nil {
-			if err == cases.ErrEndOfLine {
-				err = nil
-				break
+// - cases are dispatched to a pool of workers
+// - cases that capture or reference variables run in the order they were read, see scheduler.group
+// - rr is the aggregated result of the cases processed so far, even if err is returned
+// - err is returned when the run could not be completed (e.g. the cases could not be read)
+// - once ctx is canceled no more cases are dispatched, the cases in flight are canceled
+//   and still torn down, and the results processed so far are reported (ErrRunInterrupted)
+// - cases not started before ctx was canceled, including the rest of the stream, are reported as canceled
+func (t *Tester) Run(ctx context.Context) (rr cases.RunResult, err error) {
+	start := time.Now()
+	sc := newScheduler()
+
+	// workers
+	jobs := make(chan job)
+	done := make(chan job)
+	var wg sync.WaitGroup
+	for i := 0; i < t.workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := range jobs {
+				sc.acquire(&j)
+				if j.err != nil {
+					j.r = cases.Result{Name: j.c.Name, File: j.c.File, Status: cases.StatusErrored, Err: j.err}
+				} else {
+					j.r = t.test(ctx, &j.c)
+				}
+				sc.release(&j)
+				done <- j
 			}
-			return
+		}()
+	}
+
+	// dispatcher
+	var errRead error
+	stop := make(chan struct{})
+	go func() {
+		defer func() {
+			close(jobs)
+			wg.Wait()
+			close(done)
+		}()
+
+		for i := 0; ; i++ {
+			// read case
+			c, e := t.rd.Read()
+			if e != nil {
+				if e != cases.ErrEndOfLine {
+					errRead = e
+				}
+				return
+			}
+			// dispatch case, unless the run was interrupted
+			// - the rest of the stream is drained, so that unstarted cases are reported as canceled
+			if ctx.Err() != nil {
+				done <- job{index: i, c: c, r: t.test(ctx, &c)}
+				continue
+			}
+			select {
+			case jobs <- sc.schedule(i, c):
+			case <-stop:
+				return
+			case <-ctx.Done():
+				done <- job{index: i, c: c, r: t.test(ctx, &c)}
+			}
+		}
+	}()
+
+	// collect results
+	var results []job
+	for j := range done {
+		results = append(results, j)
+		// report case
+		for _, rp := range t.rp {
+			if e := rp.ReportResult(j.r); e != nil && err == nil {
+				err = e
+				close(stop)
+			}
+		}
+	}
+	if errRead != nil {
+		err = errRead
+	}
+	// - an interrupted run is reported as such, over the errors it caused (e.g. a read error)
+	if ctx.Err() != nil {
+		err = fmt.Errorf("%w - %v", ErrRunInterrupted, context.Cause(ctx))
+	}
+	sort.Slice(results, func(i, k int) bool { return results[i].index < results[k].index })
+	for _, j := range results {
+		rr.Add(j.r)
+	}
+	rr.Duration = time.Since(start)
+
+	// report run
+	for _, rp := range t.rp {
+		if e := rp.ReportRun(rr); e != nil && err == nil {
+			err = e
+		}
+	}
+
+	return
+}
+
+// test tests a case, turning any error into an errored or timed out result.
+// - a case that did not start before the run was interrupted is canceled
+func (t *Tester) test(ctx context.Context, c *cases.Case) (r cases.Result) {
+	if c.Skip {
+		r = cases.Result{Name: c.Name, File: c.File, Status: cases.StatusSkipped}
+		return
+	}
+	if ctx.Err() != nil {
+		r = cases.Result{Name: c.Name, File: c.File, Status: cases.StatusCanceled}
+		return
+	}
+
+	start := time.Now()
+	r, err := t.ct.Test(ctx, c)
+	if err != nil {
+		r.Status = cases.StatusErrored
+		if errors.Is(err, ErrTesterTimeout) {
+			r.Status = cases.StatusTimeout
+		}
+		r.Err = err
+	}
+	r.Name = c.Name
+	r.File = c.File
+	r.Started = start
+	r.Duration = time.Since(start)
+	return
+}
+
+// newScheduler creates a new scheduler.
+func newScheduler() *scheduler {
+	return &scheduler{
+		groups:   make(map[string]*group),
+		captured: make(map[string]string),
+	}
+}
+
+// scheduler orders the execution of cases that share state.
+// - serial cases run alone, while the rest of the cases share the run
+// - cases of the same group run one at a time, in the order they were read
+type scheduler struct {
+	// serial is locked exclusively by serial cases and shared by the rest.
+	serial sync.RWMutex
+	// mu guards the groups.
+	mu sync.Mutex
+	// groups is the set of groups by name.
+	groups map[string]*group
+	// captured is the group of the last case that captured each variable, by name.
+	captured map[string]string
+}
+
+// group is a set of cases that must run one at a time.
+type group struct {
+	// cond signals a change of turn.
+	cond *sync.Cond
+	// next is the ticket of the case whose turn it is.
+	next int
+	// tickets is the number of tickets handed out.
+	tickets int
+}
+
+// schedule creates the job of a case, handing out its ticket within its group.
+func (s *scheduler) schedule(index int, c cases.Case) (j job) {
+	j = job{index: index, c: c}
+	j.c.Group, j.err = s.group(&c)
+	if j.c.Group == "" {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	g, ok := s.groups[j.c.Group]
+	if !ok {
+		g = &group{cond: sync.NewCond(&s.mu)}
+		s.groups[j.c.Group] = g
+	}
+	j.ticket = g.tickets
+	g.tickets++
+	return
+}
+
+// group returns the group a case runs in, so that variables are captured before they are referenced.
+// - a case without a group that references a variable joins the group of the case that captured it
+// - a case without a group that captures variables joins the implicit group of variables
+// - a case that references a variable captured by a case of another group can not be ordered (ErrVariableGroup)
+// - called by the dispatcher only, in the order the cases were read
+func (s *scheduler) group(c *cases.Case) (name string, err error) {
+	name = c.Group
+	captures := c.Captures()
+	own := make(map[string]bool, len(captures))
+	for _, v := range captures {
+		own[v] = true
+	}
+
+	for _, v := range c.References() {
+		g, ok := s.captured[v]
+		if !ok || own[v] {
+			continue
+		}
+		if name == "" {
+			name = g
+			continue
 		}
-		// test case
-		err = t.ct.Test(&c)
-		if err != nil {
+		if name != g {
+			err = fmt.Errorf("%w - {{ %s }} is captured by group %s, not %s", ErrVariableGroup, v, g, name)
 			return
 		}
 	}
+	if name == "" && len(captures) > 0 {
+		name = variablesGroup
+	}
 
+	for _, v := range captures {
+		s.captured[v] = name
+	}
 	return
-}
\ No newline at end of file
+}
+
+// acquire waits until the job is allowed to run.
+func (s *scheduler) acquire(j *job) {
+	// group turn
+	if j.c.Group != "" {
+		s.mu.Lock()
+		g := s.groups[j.c.Group]
+		for g.next != j.ticket {
+			g.cond.Wait()
+		}
+		s.mu.Unlock()
+	}
+
+	// serial
+	if j.c.Serial {
+		s.serial.Lock()
+		return
+	}
+	s.serial.RLock()
+}
+
+// release lets the following jobs run.
+func (s *scheduler) release(j *job) {
+	// serial
+	if j.c.Serial {
+		s.serial.Unlock()
+	} else {
+		s.serial.RUnlock()
+	}
+
+	// group turn
+	if j.c.Group != "" {
+		s.mu.Lock()
+		g := s.groups[j.c.Group]
+		g.next++
+		g.cond.Broadcast()
+		s.mu.Unlock()
+	}
+}
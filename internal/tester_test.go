@@ -1,10 +1,17 @@
 package internal_test
 
 import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/LNMMusic/tester/internal"
 	"github.com/LNMMusic/tester/internal/cases"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
 
@@ -17,15 +24,17 @@ func TestTester_Run(t *testing.T) {
 		rd.On("Read").Return(cases.Case{}, cases.ErrEndOfLine)
 		// - casetester: mock
 		ct := internal.NewCaseTesterMock()
-		ct.On("Test", &cases.Case{}).Return(nil)
+		ct.On("Test", mock.Anything, &cases.Case{}).Return(cases.Result{}, nil)
 		// - tester
-		ts := internal.NewTester(rd, ct)
+		ts := internal.NewTester(rd, ct, 1)
 
 		// act
-		err := ts.Run()
+		rr, err := ts.Run(context.Background())
 
 		// assert
 		require.NoError(t, err)
+		require.Equal(t, 0, rr.Total())
+		require.True(t, rr.Ok())
 	})
 
 	t.Run("case 2: error - error reading a case", func(t *testing.T) {
@@ -36,32 +45,381 @@ func TestTester_Run(t *testing.T) {
 		// - casetester: mock
 		ct := internal.NewCaseTesterMock()
 		// - tester
-		ts := internal.NewTester(rd, ct)
+		ts := internal.NewTester(rd, ct, 1)
 
 		// act
-		err := ts.Run()
+		_, err := ts.Run(context.Background())
 
 		// assert
 		require.Error(t, err)
 		require.EqualError(t, err, cases.ErrMalformedJSON.Error())
 	})
-	
-	t.Run("case 3: error - error testing a case", func(t *testing.T) {
+
+	t.Run("case 3: success - error testing a case is counted as errored", func(t *testing.T) {
 		// arrange
 		// - reader: mock
 		rd := cases.NewReaderMock()
-		rd.On("Read").Return(cases.Case{}, nil)
+		rd.On("Read").Return(cases.Case{Name: "case 1"}, nil).Once()
+		rd.On("Read").Return(cases.Case{}, cases.ErrEndOfLine)
 		// - casetester: mock
 		ct := internal.NewCaseTesterMock()
-		ct.On("Test", &cases.Case{}).Return(internal.ErrTesterReporter)
+		ct.On("Test", mock.Anything, &cases.Case{Name: "case 1"}).Return(cases.Result{}, internal.ErrTesterReporter)
 		// - tester
-		ts := internal.NewTester(rd, ct)
+		ts := internal.NewTester(rd, ct, 1)
 
 		// act
-		err := ts.Run()
+		rr, err := ts.Run(context.Background())
 
 		// assert
-		require.Error(t, err)
-		require.EqualError(t, err, internal.ErrTesterReporter.Error())
+		require.NoError(t, err)
+		require.Equal(t, 1, rr.Errored)
+		require.False(t, rr.Ok())
+		require.Equal(t, "case 1", rr.Results[0].Name)
+		require.Equal(t, cases.StatusErrored, rr.Results[0].Status)
+		require.ErrorIs(t, rr.Results[0].Err, internal.ErrTesterReporter)
+	})
+
+	t.Run("case 4: success - cases aggregated and reported", func(t *testing.T) {
+		// arrange
+		// - reader: mock
+		rd := cases.NewReaderMock()
+		rd.On("Read").Return(cases.Case{Name: "case 1"}, nil).Once()
+		rd.On("Read").Return(cases.Case{Name: "case 2"}, nil).Once()
+		rd.On("Read").Return(cases.Case{Name: "case 3", Skip: true}, nil).Once()
+		rd.On("Read").Return(cases.Case{}, cases.ErrEndOfLine)
+		// - casetester: mock
+		ct := internal.NewCaseTesterMock()
+		ct.On("Test", mock.Anything, &cases.Case{Name: "case 1"}).Return(cases.Result{Status: cases.StatusPassed}, nil)
+		ct.On("Test", mock.Anything, &cases.Case{Name: "case 2"}).Return(cases.Result{Status: cases.StatusFailed}, nil)
+		// - reporter: mock
+		rp := cases.NewResultReporterMock()
+		rp.On("ReportResult", mock.Anything).Return(nil).Times(3)
+		rp.On("ReportRun", mock.Anything).Return(nil).Once()
+		// - tester
+		ts := internal.NewTester(rd, ct, 1, rp)
+
+		// act
+		rr, err := ts.Run(context.Background())
+
+		// assert
+		require.NoError(t, err)
+		require.Equal(t, 1, rr.Passed)
+		require.Equal(t, 1, rr.Failed)
+		require.Equal(t, 0, rr.Errored)
+		require.Equal(t, 1, rr.Skipped)
+		require.False(t, rr.Ok())
+		ct.AssertExpectations(t)
+		rp.AssertExpectations(t)
+	})
+
+	t.Run("case 5: success - cases of a group run in order across workers", func(t *testing.T) {
+		// arrange
+		// - reader: mock
+		rd := cases.NewReaderMock()
+		for i := 0; i < 10; i++ {
+			rd.On("Read").Return(cases.Case{Name: fmt.Sprintf("case %d", i), Group: "tasks"}, nil).Once()
+		}
+		rd.On("Read").Return(cases.Case{}, cases.ErrEndOfLine)
+		// - casetester: mock
+		var mu sync.Mutex
+		var order []string
+		ct := internal.NewCaseTesterMock()
+		ct.On("Test", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			mu.Lock()
+			defer mu.Unlock()
+			order = append(order, args.Get(1).(*cases.Case).Name)
+		}).Return(cases.Result{Status: cases.StatusPassed}, nil)
+		// - tester
+		ts := internal.NewTester(rd, ct, 4)
+
+		// act
+		rr, err := ts.Run(context.Background())
+
+		// assert
+		require.NoError(t, err)
+		require.Equal(t, 10, rr.Passed)
+		for i := 0; i < 10; i++ {
+			require.Equal(t, fmt.Sprintf("case %d", i), order[i])
+			require.Equal(t, fmt.Sprintf("case %d", i), rr.Results[i].Name)
+		}
+	})
+
+	t.Run("case 6: success - timed out case is counted apart", func(t *testing.T) {
+		// arrange
+		// - reader: mock
+		rd := cases.NewReaderMock()
+		rd.On("Read").Return(cases.Case{Name: "case 1"}, nil).Once()
+		rd.On("Read").Return(cases.Case{}, cases.ErrEndOfLine)
+		// - casetester: mock
+		ct := internal.NewCaseTesterMock()
+		ct.On("Test", mock.Anything, &cases.Case{Name: "case 1"}).Return(cases.Result{}, fmt.Errorf("%w - 1s. %w", internal.ErrTesterTimeout, internal.ErrTesterRequest))
+		// - tester
+		ts := internal.NewTester(rd, ct, 1)
+
+		// act
+		rr, err := ts.Run(context.Background())
+
+		// assert
+		require.NoError(t, err)
+		require.Equal(t, 0, rr.Errored)
+		require.Equal(t, 1, rr.TimedOut)
+		require.Equal(t, 1, rr.Total())
+		require.False(t, rr.Ok())
+		require.Equal(t, cases.StatusTimeout, rr.Results[0].Status)
+	})
+
+	t.Run("case 7: error - run interrupted, results so far reported", func(t *testing.T) {
+		// arrange
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		// - reader: mock
+		rd := cases.NewReaderMock()
+		rd.On("Read").Return(cases.Case{Name: "case 1"}, nil).Once()
+		rd.On("Read").Return(cases.Case{Name: "case 2"}, nil).Once()
+		rd.On("Read").Return(cases.Case{Name: "case 3"}, nil).Once()
+		rd.On("Read").Return(cases.Case{}, cases.ErrEndOfLine)
+		// - casetester: mock, interrupted while testing the first case
+		ct := internal.NewCaseTesterMock()
+		ct.On("Test", mock.Anything, &cases.Case{Name: "case 1"}).Run(func(args mock.Arguments) {
+			cancel()
+		}).Return(cases.Result{Status: cases.StatusPassed}, nil).Once()
+		// - reporter: mock
+		rp := cases.NewResultReporterMock()
+		rp.On("ReportResult", mock.Anything).Return(nil)
+		rp.On("ReportRun", mock.Anything).Return(nil).Once()
+		// - tester
+		ts := internal.NewTester(rd, ct, 1, rp)
+
+		// act
+		rr, err := ts.Run(ctx)
+
+		// assert
+		require.ErrorIs(t, err, internal.ErrRunInterrupted)
+		require.EqualError(t, err, "tester: run interrupted - context canceled")
+		require.Equal(t, 1, rr.Passed)
+		require.Equal(t, 2, rr.Canceled)
+		require.Equal(t, 0, rr.Skipped)
+		require.Equal(t, "case 1", rr.Results[0].Name)
+		require.Equal(t, cases.StatusCanceled, rr.Results[1].Status)
+		require.Equal(t, cases.StatusCanceled, rr.Results[2].Status)
+		rd.AssertExpectations(t)
+		ct.AssertExpectations(t)
+		rp.AssertExpectations(t)
+	})
+
+	t.Run("case 8: error - run interrupted, stream of the reader stopped", func(t *testing.T) {
+		// arrange
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		// - reader: stream blocked on cases that are never read
+		dc := json.NewDecoder(strings.NewReader(
+			`[{"case_name":"case 1"},{"case_name":"case 2"},{"case_name":"case 3"},{"case_name":"case 4"}]`,
+		))
+		rd := cases.NewReaderJSON(dc, make(chan cases.CaseErr))
+		streamed := make(chan struct{})
+		go func() {
+			defer close(streamed)
+			rd.Stream(ctx)
+		}()
+		// - casetester: mock, interrupted while testing the first case
+		ct := internal.NewCaseTesterMock()
+		ct.On("Test", mock.Anything, &cases.Case{Name: "case 1"}).Run(func(args mock.Arguments) {
+			cancel()
+		}).Return(cases.Result{Status: cases.StatusPassed}, nil).Once()
+		// - tester
+		ts := internal.NewTester(rd, ct, 1)
+
+		// act
+		rr, err := ts.Run(ctx)
+
+		// assert
+		require.ErrorIs(t, err, internal.ErrRunInterrupted)
+		require.EqualError(t, err, "tester: run interrupted - context canceled")
+		require.Equal(t, 1, rr.Passed)
+		require.Equal(t, 0, rr.Errored)
+		require.LessOrEqual(t, rr.Canceled, 3)
+		select {
+		case <-streamed:
+		case <-time.After(time.Second):
+			t.Fatal("stream of the reader still running")
+		}
+		ct.AssertExpectations(t)
+	})
+
+	t.Run("case 9: success - cases run concurrently up to the number of workers", func(t *testing.T) {
+		// arrange
+		// - reader: mock
+		rd := cases.NewReaderMock()
+		for i := 0; i < 4; i++ {
+			rd.On("Read").Return(cases.Case{Name: fmt.Sprintf("case %d", i)}, nil).Once()
+		}
+		rd.On("Read").Return(cases.Case{}, cases.ErrEndOfLine)
+		// - casetester: mock, blocked until every worker is testing a case
+		var mu sync.Mutex
+		running, overlapped := 0, true
+		all := make(chan struct{})
+		ct := internal.NewCaseTesterMock()
+		ct.On("Test", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			mu.Lock()
+			running++
+			if running == 4 {
+				close(all)
+			}
+			mu.Unlock()
+			select {
+			case <-all:
+			case <-time.After(time.Second):
+				mu.Lock()
+				overlapped = false
+				mu.Unlock()
+			}
+		}).Return(cases.Result{Status: cases.StatusPassed}, nil)
+		// - tester
+		ts := internal.NewTester(rd, ct, 4)
+
+		// act
+		rr, err := ts.Run(context.Background())
+
+		// assert
+		require.NoError(t, err)
+		require.Equal(t, 4, rr.Passed)
+		require.True(t, overlapped)
+	})
+
+	t.Run("case 10: success - serial case never overlaps other cases", func(t *testing.T) {
+		// arrange
+		// - reader: mock, a serial case between concurrent ones
+		rd := cases.NewReaderMock()
+		for i := 0; i < 9; i++ {
+			rd.On("Read").Return(cases.Case{Name: fmt.Sprintf("case %d", i), Serial: i%3 == 1}, nil).Once()
+		}
+		rd.On("Read").Return(cases.Case{}, cases.ErrEndOfLine)
+		// - casetester: mock, recording the cases running alongside each one
+		var mu sync.Mutex
+		running, maxRunning, overlaps := 0, 0, 0
+		serial := false
+		ct := internal.NewCaseTesterMock()
+		ct.On("Test", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			c := args.Get(1).(*cases.Case)
+			mu.Lock()
+			if serial || (c.Serial && running > 0) {
+				overlaps++
+			}
+			serial = c.Serial
+			running++
+			maxRunning = max(maxRunning, running)
+			mu.Unlock()
+
+			time.Sleep(10 * time.Millisecond)
+
+			mu.Lock()
+			running--
+			if c.Serial {
+				serial = false
+			}
+			mu.Unlock()
+		}).Return(cases.Result{Status: cases.StatusPassed}, nil)
+		// - tester
+		ts := internal.NewTester(rd, ct, 4)
+
+		// act
+		rr, err := ts.Run(context.Background())
+
+		// assert
+		require.NoError(t, err)
+		require.Equal(t, 9, rr.Passed)
+		require.Equal(t, 0, overlaps)
+		require.Greater(t, maxRunning, 1)
+	})
+
+	t.Run("case 11: success - case referencing a variable runs after the case capturing it", func(t *testing.T) {
+		// arrange
+		// - reader: mock
+		rd := cases.NewReaderMock()
+		rd.On("Read").Return(cases.Case{Name: "create", Capture: cases.Capture{Body: map[string]string{"$.id": "task_id"}}}, nil).Once()
+		rd.On("Read").Return(cases.Case{Name: "get", Request: cases.Request{Path: "/tasks/{{ task_id }}"}}, nil).Once()
+		rd.On("Read").Return(cases.Case{}, cases.ErrEndOfLine)
+		// - casetester: mock, the capturing case is slow
+		var mu sync.Mutex
+		var order []string
+		ct := internal.NewCaseTesterMock()
+		ct.On("Test", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			c := args.Get(1).(*cases.Case)
+			if c.Name == "create" {
+				time.Sleep(20 * time.Millisecond)
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			order = append(order, c.Name)
+		}).Return(cases.Result{Status: cases.StatusPassed}, nil)
+		// - tester
+		ts := internal.NewTester(rd, ct, 4)
+
+		// act
+		rr, err := ts.Run(context.Background())
+
+		// assert
+		require.NoError(t, err)
+		require.Equal(t, 2, rr.Passed)
+		require.Equal(t, []string{"create", "get"}, order)
+	})
+
+	t.Run("case 12: success - case referencing a variable captured by another group is errored", func(t *testing.T) {
+		// arrange
+		// - reader: mock
+		rd := cases.NewReaderMock()
+		rd.On("Read").Return(cases.Case{Name: "create", Group: "a", Capture: cases.Capture{Body: map[string]string{"$.id": "task_id"}}}, nil).Once()
+		rd.On("Read").Return(cases.Case{Name: "get", Group: "b", Request: cases.Request{Path: "/tasks/{{ task_id }}"}}, nil).Once()
+		rd.On("Read").Return(cases.Case{}, cases.ErrEndOfLine)
+		// - casetester: mock
+		ct := internal.NewCaseTesterMock()
+		ct.On("Test", mock.Anything, mock.Anything).Return(cases.Result{Status: cases.StatusPassed}, nil).Once()
+		// - tester
+		ts := internal.NewTester(rd, ct, 4)
+
+		// act
+		rr, err := ts.Run(context.Background())
+
+		// assert
+		require.NoError(t, err)
+		require.Equal(t, 1, rr.Passed)
+		require.Equal(t, 1, rr.Errored)
+		require.ErrorIs(t, rr.Results[1].Err, internal.ErrVariableGroup)
+		require.EqualError(t, rr.Results[1].Err, "tester: variable captured by another group - {{ task_id }} is captured by group a, not b")
+		ct.AssertExpectations(t)
+	})
+
+	t.Run("case 13: error - run interrupted, rest of the stream reported as canceled", func(t *testing.T) {
+		// arrange
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		// - reader: stream already read
+		dc := json.NewDecoder(strings.NewReader(
+			`[{"case_name":"case 1"},{"case_name":"case 2"},{"case_name":"case 3","skip":true},{"case_name":"case 4"}]`,
+		))
+		rd := cases.NewReaderJSON(dc, make(chan cases.CaseErr, 4))
+		rd.Stream(ctx)
+		// - casetester: mock, interrupted while testing the first case
+		ct := internal.NewCaseTesterMock()
+		ct.On("Test", mock.Anything, &cases.Case{Name: "case 1"}).Run(func(args mock.Arguments) {
+			cancel()
+		}).Return(cases.Result{Status: cases.StatusPassed}, nil).Once()
+		// - tester
+		ts := internal.NewTester(rd, ct, 1)
+
+		// act
+		rr, err := ts.Run(ctx)
+
+		// assert
+		require.ErrorIs(t, err, internal.ErrRunInterrupted)
+		require.Equal(t, 1, rr.Passed)
+		require.Equal(t, 1, rr.Skipped)
+		require.Equal(t, 2, rr.Canceled)
+		require.Len(t, rr.Results, 4)
+		require.Equal(t, "case 2", rr.Results[1].Name)
+		require.Equal(t, cases.StatusCanceled, rr.Results[1].Status)
+		require.Equal(t, "case 4", rr.Results[3].Name)
+		require.Equal(t, cases.StatusCanceled, rr.Results[3].Status)
+		ct.AssertExpectations(t)
 	})
-}
\ No newline at end of file
+}